@@ -1,11 +1,17 @@
 package main
 
 import (
+	"flag"
+	"fmt"
 	"log"
 	"os"
+	"strings"
 	"time"
 
+	"github.com/xeniasokk/field-switcher/internal/adapters/formatter"
+	"github.com/xeniasokk/field-switcher/internal/adapters/presenter"
 	"github.com/xeniasokk/field-switcher/internal/app"
+	"github.com/xeniasokk/field-switcher/internal/application/usecase/merge"
 	"github.com/xeniasokk/field-switcher/pkg/lifecycle"
 )
 
@@ -14,10 +20,100 @@ const (
 )
 
 func main() {
-	log.SetOutput(os.Stdout)
+	// журнал пишется в stderr, чтобы не смешиваться с выводом форматтера (JSON, PDF, PNG)
+	log.SetOutput(os.Stderr)
 	log.SetFlags(log.LstdFlags | log.Lmicroseconds)
 
-	a, err := app.NewApp()
+	cfg := app.DefaultConfig()
+	flag.StringVar(&cfg.ValidationFile, "validation-file", cfg.ValidationFile,
+		"path to a JSON file with validation policies (replaces the built-in defaults)")
+	flag.StringVar(&cfg.Format, "format", cfg.Format,
+		fmt.Sprintf("output format: %s (default text)", strings.Join(formatter.DefaultRegistry().Names(), ", ")))
+	flag.StringVar(&cfg.Accept, "accept", cfg.Accept, "pick the output format by an HTTP Accept header value")
+	flag.Func("locale", fmt.Sprintf("language of headings and labels: %s (default %s)",
+		strings.Join(formatter.Locales(), ", "), formatter.DefaultLocale), func(v string) error {
+		locale, err := formatter.ParseLocale(v)
+		if err != nil {
+			return err
+		}
+		cfg.Locale = locale
+		return nil
+	})
+	flag.StringVar(&cfg.Theme, "theme", cfg.Theme,
+		fmt.Sprintf("color theme: %s", strings.Join(formatter.ThemeNames(), ", ")))
+	flag.StringVar(&cfg.ThemeFile, "theme-file", cfg.ThemeFile, "path to a JSON theme file (overrides -theme)")
+	flag.Func("color", "color output: auto, always, never (default auto)", func(v string) error {
+		switch mode := formatter.ColorMode(v); mode {
+		case formatter.ColorAuto, formatter.ColorAlways, formatter.ColorNever:
+			cfg.Color = mode
+			return nil
+		default:
+			return fmt.Errorf("unknown color mode %q", v)
+		}
+	})
+	flag.IntVar(&cfg.Width, "width", cfg.Width, "output width in columns (0 = detect from terminal)")
+	flag.BoolVar(&cfg.Boxes, "boxes", cfg.Boxes, "draw sections in box-drawing frames")
+	flag.Func("layout", "section layout: stacked, side-by-side (default stacked)", func(v string) error {
+		switch layout := formatter.TextLayout(v); layout {
+		case formatter.LayoutStacked, formatter.LayoutSideBySide:
+			cfg.Layout = layout
+			return nil
+		default:
+			return fmt.Errorf("unknown layout %q", v)
+		}
+	})
+	flag.StringVar(&cfg.TemplateFile, "template", cfg.TemplateFile, "path to a text/template file for custom output")
+	flag.BoolVar(&cfg.StrictTemplate, "strict-template", cfg.StrictTemplate, "fail when the template references a missing field")
+	flag.Func("mode", fmt.Sprintf("presentation mode: %s (default %s)",
+		strings.Join(presenter.Modes(), ", "), presenter.DefaultMode), func(v string) error {
+		mode, err := presenter.ParseMode(v)
+		if err != nil {
+			return err
+		}
+		cfg.Mode = mode
+		return nil
+	})
+	flag.StringVar(&cfg.HighlightFile, "highlight-file", cfg.HighlightFile,
+		"path to a JSON file with highlight rules: which qualities and stack items to emphasise")
+	flag.Func("csv-columns", fmt.Sprintf("comma-separated csv/tsv columns: %s", strings.Join(formatter.CSVColumnNames(), ", ")),
+		func(v string) error {
+			columns, err := formatter.ParseCSVColumns(v)
+			if err != nil {
+				return err
+			}
+			cfg.CSVColumns = columns
+			return nil
+		})
+	flag.StringVar(&cfg.CSVListSeparator, "csv-list-separator", cfg.CSVListSeparator,
+		"separator for multi-valued csv/tsv fields such as stack and traits (default \"; \")")
+	flag.StringVar(&cfg.TeamFile, "team-file", cfg.TeamFile,
+		"path to a JSON array of childhood dreams, or a .yaml/.yml file with a list or yaml output documents; renders the whole team with a batch format (csv, tsv, dot, mermaid)")
+	flag.StringVar(&cfg.MergeFile, "merge-file", cfg.MergeFile,
+		"path to several childhood dreams in the -team-file format, merged into one adult identity")
+	flag.Func("merge-strategy", "how -merge-file resolves a quality found in several dreams: max, first or average (default max)",
+		func(v string) error {
+			strategy := merge.Strategy(v)
+			if !strategy.Valid() {
+				return fmt.Errorf("unknown merge strategy %q", v)
+			}
+			cfg.MergeStrategy = strategy
+			return nil
+		})
+	flag.BoolVar(&cfg.FrontMatter, "front-matter", cfg.FrontMatter,
+		"start markdown output with a YAML front matter block")
+	flag.Func("issued-at", "issue date printed on the pdf certificate, YYYY-MM-DD (default today)", func(v string) error {
+		t, err := time.Parse(time.DateOnly, v)
+		if err != nil {
+			return err
+		}
+		cfg.IssuedAt = t
+		return nil
+	})
+	flag.BoolVar(&cfg.Accessible, "accessible", cfg.Accessible,
+		"plain-text output for screen readers: no colors or glyphs, numbered lists")
+	flag.Parse()
+
+	a, err := app.NewAppWithConfig(cfg)
 	if err != nil {
 		log.Printf("Failed to initialize application: %v", err)
 		os.Exit(1)
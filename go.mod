@@ -2,10 +2,17 @@ module github.com/xeniasokk/field-switcher
 
 go 1.22
 
-require github.com/fatih/color v1.18.0
+require (
+	github.com/fatih/color v1.18.0
+	github.com/go-pdf/fpdf v0.9.0
+	github.com/mattn/go-isatty v0.0.20
+	golang.org/x/image v0.18.0
+	golang.org/x/term v0.24.0
+	golang.org/x/text v0.19.0
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require (
 	github.com/mattn/go-colorable v0.1.13 // indirect
-	github.com/mattn/go-isatty v0.0.20 // indirect
 	golang.org/x/sys v0.25.0 // indirect
 )
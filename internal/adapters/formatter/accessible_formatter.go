@@ -0,0 +1,182 @@
+package formatter
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/xeniasokk/field-switcher/internal/ports"
+	"github.com/xeniasokk/field-switcher/pkg/document"
+)
+
+var _ ports.FormatterPort = (*AccessibleFormatter)(nil)
+
+// AccessibleFormatter: без ANSI и декоративных символов, списки нумеруются, пунктуация ASCII.
+type AccessibleFormatter struct {
+	catalog Catalog
+}
+
+type AccessibleOption func(*AccessibleFormatter)
+
+func WithAccessibleCatalog(catalog Catalog) AccessibleOption {
+	return func(f *AccessibleFormatter) {
+		f.catalog = catalog
+	}
+}
+
+func NewAccessibleFormatter(opts ...AccessibleOption) *AccessibleFormatter {
+	f := &AccessibleFormatter{}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
+}
+
+type accessibleSection struct {
+	name  string
+	write func(b *strings.Builder)
+}
+
+func (f *AccessibleFormatter) Format(ctx context.Context, vm ports.ViewModel) (string, error) {
+	_ = ctx
+
+	c := f.catalog
+	section := func(key MessageKey) string {
+		return strings.ToLower(c.T(key))
+	}
+
+	doc := f.catalog.Localize(vm.Document())
+	var b strings.Builder
+	if title := doc.Title.String(); title != "" {
+		writeSentence(&b, c.Label(MsgTitle)+" "+title)
+		b.WriteString("\n")
+	}
+
+	sections := make([]accessibleSection, 0, len(doc.Sections)+1)
+	for _, s := range doc.Sections {
+		sections = append(sections, accessibleSection{section(MessageKey(s.Key)), func(b *strings.Builder) {
+			for _, block := range s.Blocks {
+				switch block := block.(type) {
+				case document.Fields:
+					for _, field := range block {
+						writeSentence(b, c.Label(MessageKey(field.Label))+" "+accessibleText(c, field.Value))
+					}
+				case document.List:
+					label := c.T(MessageKey(block.Label))
+					if block.Label == "" {
+						label = c.T(MessageKey(s.Key))
+					}
+					writeNumbered(b, c, label, accessibleItems(c, block))
+				}
+			}
+		}})
+	}
+	if len(doc.Notes) > 0 || len(doc.Quotes) > 0 {
+		sections = append(sections, accessibleSection{section(MsgSummary), func(b *strings.Builder) {
+			for _, note := range doc.Notes {
+				writeSentence(b, note.String())
+			}
+			for _, quote := range doc.Quotes {
+				text := quote.Text.String()
+				if quote.Label != "" {
+					text = c.Label(MessageKey(quote.Label)) + " " + text
+				}
+				writeSentence(b, text)
+			}
+		}})
+	}
+
+	for i, s := range sections {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		writeSentence(&b, c.Tf(MsgSectionStart, i+1, len(sections), s.name))
+		s.write(&b)
+		writeSentence(&b, c.Tf(MsgSectionEnd, s.name))
+	}
+
+	return b.String(), nil
+}
+
+// accessibleText произносит подпись уточнения вместо скобок: "Поле, окружение: Команда".
+func accessibleText(c Catalog, t document.Text) string {
+	var b strings.Builder
+	for _, s := range t {
+		if s.Label != "" {
+			b.WriteString(", " + c.Label(MessageKey(s.Label)) + " " + s.Text)
+			continue
+		}
+		b.WriteString(s.Text)
+	}
+	return b.String()
+}
+
+func accessibleItems(c Catalog, list document.List) []string {
+	items := make([]string, 0, len(list.Items))
+	for _, it := range list.Items {
+		item := accessibleText(c, it.Name)
+		if detail := accessibleText(c, it.Detail); detail != "" {
+			item += ": " + detail
+		}
+		if len(it.Refs) > 0 {
+			refs := strings.Join(it.Refs, ", ")
+			if list.RefsLabel != "" {
+				refs = c.Label(MessageKey(list.RefsLabel)) + " " + refs
+			}
+			item += ", " + refs
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+func writeNumbered(b *strings.Builder, c Catalog, label string, items []string) {
+	if len(items) == 0 {
+		return
+	}
+	writeSentence(b, fmt.Sprintf("%s, %d %s", label, len(items), c.Plural(MsgItems, len(items))))
+	for i, item := range items {
+		writeSentence(b, fmt.Sprintf("%d. %s", i+1, item))
+	}
+}
+
+// writeSentence пишет строку, заканчивая её точкой, чтобы диктор делал паузу.
+func writeSentence(b *strings.Builder, s string) {
+	s = PlainText(s)
+	if s == "" {
+		return
+	}
+	b.WriteString(s)
+	if !strings.ContainsRune(".!?:", rune(s[len(s)-1])) {
+		b.WriteString(".")
+	}
+	b.WriteString("\n")
+}
+
+var plainReplacer = strings.NewReplacer(
+	"—", "-", "–", "-", "‑", "-", "−", "-",
+	"…", "...",
+	"«", `"`, "»", `"`, "“", `"`, "”", `"`, "„", `"`,
+	"‘", "'", "’", "'",
+	"→", "->", "←", "<-",
+	"•", "", "·", "",
+	"№", "N",
+)
+
+// PlainText заменяет типографские символы ASCII-аналогами, прочие не буквы и не цифры отбрасывает.
+func PlainText(s string) string {
+	s = plainReplacer.Replace(StripANSI(s))
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r < unicode.MaxASCII && (r >= 0x20 || r == '\t' || r == '\n'):
+			b.WriteRune(r)
+		case unicode.IsLetter(r), unicode.IsDigit(r), unicode.Is(unicode.Mn, r):
+			b.WriteRune(r)
+		case unicode.IsSpace(r):
+			b.WriteRune(' ')
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
@@ -0,0 +1,254 @@
+package formatter
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/xeniasokk/field-switcher/pkg/document"
+	appErrors "github.com/xeniasokk/field-switcher/pkg/errors"
+)
+
+type Locale string
+
+const (
+	LocaleRU Locale = "ru"
+	LocaleEN Locale = "en"
+
+	DefaultLocale = LocaleRU
+)
+
+type MessageKey string
+
+const (
+	MsgTitle        MessageKey = "title"
+	MsgSources      MessageKey = "sources"
+	MsgChildhood    MessageKey = "childhood"
+	MsgAdult        MessageKey = "adult"
+	MsgName         MessageKey = "name"
+	MsgRole         MessageKey = "role"
+	MsgField        MessageKey = "field"
+	MsgEnvironment  MessageKey = "environment"
+	MsgDescription  MessageKey = "description"
+	MsgQualities    MessageKey = "qualities"
+	MsgTraits       MessageKey = "traits"
+	MsgStack        MessageKey = "stack"
+	MsgComment      MessageKey = "comment"
+	MsgSummary      MessageKey = "summary"
+	MsgDreams       MessageKey = "dreams"
+	MsgFromDreams   MessageKey = "from_dreams"
+	MsgNotPreserved MessageKey = "not_preserved"
+	MsgSectionStart MessageKey = "section_start"
+	MsgSectionEnd   MessageKey = "section_end"
+	MsgCertificate  MessageKey = "certificate"
+	MsgStillInGame  MessageKey = "still_in_game"
+	MsgIssued       MessageKey = "issued"
+
+	// служебные фразы, которые presenter кладёт в документ ключами с аргументами
+	MsgAppTitle       MessageKey = "app_title"
+	MsgPreservedCount MessageKey = "preserved_count"
+	MsgMainAlly       MessageKey = "main_ally"
+	MsgSummaryLine    MessageKey = "summary_line"
+	// формы множественного числа разделены "|": две для английского, три для русского
+	MsgItems MessageKey = "items"
+)
+
+// Заголовки хранятся в обычном регистре: TextFormatter и SVGFormatter сами переводят их в верхний.
+var catalogs = map[Locale]map[MessageKey]string{
+	LocaleRU: {
+		MsgTitle:        "Заголовок",
+		MsgSources:      "Исходные мечты",
+		MsgChildhood:    "Детская мечта",
+		MsgAdult:        "Взрослая роль",
+		MsgName:         "Название",
+		MsgRole:         "Роль",
+		MsgField:        "Поле",
+		MsgEnvironment:  "окружение",
+		MsgDescription:  "Описание",
+		MsgQualities:    "Качества",
+		MsgTraits:       "Сохранённые качества",
+		MsgStack:        "Стек",
+		MsgComment:      "Комментарий",
+		MsgSummary:      "Итог",
+		MsgDreams:       "Мечты",
+		MsgFromDreams:   "из мечт",
+		MsgNotPreserved: "не сохранено",
+		MsgSectionStart: "Раздел %d из %d: %s",
+		MsgSectionEnd:   "Конец раздела: %s",
+		MsgCertificate:  "Сертификат",
+		MsgStillInGame:  "Ты всё ещё в игре",
+		MsgIssued:       "Выдан %s",
+		MsgItems:        "пункт|пункта|пунктов",
+
+		MsgAppTitle:       "field-switcher — трансформация мечты",
+		MsgPreservedCount: "Сохранено качеств: %s",
+		MsgMainAlly:       "%s — твой главный союзник на новом поле",
+		MsgSummaryLine:    "%s — %s. Поле: %s (%s). Качества: %s",
+	},
+	LocaleEN: {
+		MsgTitle:        "Title",
+		MsgSources:      "Source dreams",
+		MsgChildhood:    "Childhood dream",
+		MsgAdult:        "Adult role",
+		MsgName:         "Name",
+		MsgRole:         "Role",
+		MsgField:        "Field",
+		MsgEnvironment:  "environment",
+		MsgDescription:  "Description",
+		MsgQualities:    "Qualities",
+		MsgTraits:       "Preserved qualities",
+		MsgStack:        "Stack",
+		MsgComment:      "Comment",
+		MsgSummary:      "Summary",
+		MsgDreams:       "Dreams",
+		MsgFromDreams:   "from dreams",
+		MsgNotPreserved: "not preserved",
+		MsgSectionStart: "Section %d of %d: %s",
+		MsgSectionEnd:   "End of section: %s",
+		MsgCertificate:  "Certificate",
+		MsgStillInGame:  "You're still in the game",
+		MsgIssued:       "Issued %s",
+		MsgItems:        "item|items",
+
+		MsgAppTitle:       "field-switcher — dream transformation",
+		MsgPreservedCount: "Qualities preserved: %s",
+		MsgMainAlly:       "%s is your main ally on the new field",
+		MsgSummaryLine:    "%s — %s. Field: %s (%s). Qualities: %s",
+	},
+}
+
+// Catalog: нулевое значение — каталог локали по умолчанию.
+type Catalog struct {
+	locale   Locale
+	messages map[MessageKey]string
+}
+
+func Locales() []string {
+	names := make([]string, 0, len(catalogs))
+	for l := range catalogs {
+		names = append(names, string(l))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParseLocale принимает и полные обозначения вроде "en-US" или "ru_RU.UTF-8".
+func ParseLocale(s string) (Locale, error) {
+	tag := strings.ToLower(strings.TrimSpace(s))
+	if i := strings.IndexAny(tag, "-_."); i >= 0 {
+		tag = tag[:i]
+	}
+	if _, ok := catalogs[Locale(tag)]; !ok {
+		return "", appErrors.NewValidationError(
+			fmt.Sprintf("unsupported locale %q, available: %s", s, strings.Join(Locales(), ", ")),
+		)
+	}
+	return Locale(tag), nil
+}
+
+func NewCatalog(locale Locale) (Catalog, error) {
+	messages, ok := catalogs[locale]
+	if !ok {
+		return Catalog{}, appErrors.NewValidationError(
+			fmt.Sprintf("unsupported locale %q, available: %s", locale, strings.Join(Locales(), ", ")),
+		)
+	}
+	return Catalog{locale: locale, messages: messages}, nil
+}
+
+func (c Catalog) Locale() Locale {
+	if c.locale == "" {
+		return DefaultLocale
+	}
+	return c.locale
+}
+
+func (c Catalog) T(key MessageKey) string {
+	if msg, ok := c.messages[key]; ok {
+		return msg
+	}
+	if msg, ok := catalogs[DefaultLocale][key]; ok {
+		return msg
+	}
+	return string(key)
+}
+
+func (c Catalog) Tf(key MessageKey, args ...any) string {
+	return fmt.Sprintf(c.T(key), args...)
+}
+
+func (c Catalog) Label(key MessageKey) string {
+	return c.T(key) + ":"
+}
+
+func (c Catalog) Heading(key MessageKey) string {
+	return strings.ToUpper(c.T(key))
+}
+
+func (c Catalog) Plural(key MessageKey, n int) string {
+	form, err := templatePlural(n, strings.Split(c.T(key), "|")...)
+	if err != nil {
+		return c.T(key)
+	}
+	return form
+}
+
+// Localize возвращает копию документа с переведёнными фразами-ключами.
+func (c Catalog) Localize(doc document.Document) document.Document {
+	doc.Title = c.localizeText(doc.Title)
+	doc.Sections = slices.Clone(doc.Sections)
+	for i := range doc.Sections {
+		doc.Sections[i].Blocks = c.localizeBlocks(doc.Sections[i].Blocks)
+	}
+	doc.Notes = slices.Clone(doc.Notes)
+	for i := range doc.Notes {
+		doc.Notes[i] = c.localizeText(doc.Notes[i])
+	}
+	doc.Quotes = slices.Clone(doc.Quotes)
+	for i := range doc.Quotes {
+		doc.Quotes[i].Text = c.localizeText(doc.Quotes[i].Text)
+	}
+	return doc
+}
+
+func (c Catalog) localizeBlocks(blocks []document.Block) []document.Block {
+	blocks = slices.Clone(blocks)
+	for i, block := range blocks {
+		switch b := block.(type) {
+		case document.Fields:
+			b = slices.Clone(b)
+			for j := range b {
+				b[j].Value = c.localizeText(b[j].Value)
+			}
+			blocks[i] = b
+		case document.List:
+			b.Items = slices.Clone(b.Items)
+			for j := range b.Items {
+				b.Items[j].Name = c.localizeText(b.Items[j].Name)
+				b.Items[j].Detail = c.localizeText(b.Items[j].Detail)
+				b.Items[j].Blocks = c.localizeBlocks(b.Items[j].Blocks)
+			}
+			blocks[i] = b
+		}
+	}
+	return blocks
+}
+
+func (c Catalog) localizeText(t document.Text) document.Text {
+	if !slices.ContainsFunc(t, func(s document.Span) bool { return s.Key != "" }) {
+		return t
+	}
+	t = slices.Clone(t)
+	for i, s := range t {
+		if s.Key == "" {
+			continue
+		}
+		args := make([]any, len(s.Args))
+		for j, a := range s.Args {
+			args[j] = a
+		}
+		t[i] = document.Span{Text: c.Tf(MessageKey(s.Key), args...), Emphasis: s.Emphasis, Label: s.Label}
+	}
+	return t
+}
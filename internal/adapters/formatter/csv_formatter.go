@@ -0,0 +1,249 @@
+package formatter
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/xeniasokk/field-switcher/internal/ports"
+	"github.com/xeniasokk/field-switcher/pkg/document"
+	appErrors "github.com/xeniasokk/field-switcher/pkg/errors"
+)
+
+var (
+	_ ports.StreamFormatterPort      = (*CSVFormatter)(nil)
+	_ ports.BatchStreamFormatterPort = (*CSVFormatter)(nil)
+)
+
+type CSVColumn string
+
+const (
+	ColumnTitle       CSVColumn = "title"
+	ColumnType        CSVColumn = "type"
+	ColumnDisplayName CSVColumn = "display_name"
+	ColumnDesiredRole CSVColumn = "desired_role"
+	ColumnRoleTitle   CSVColumn = "role_title"
+	ColumnField       CSVColumn = "field"
+	ColumnStack       CSVColumn = "stack"
+	ColumnQualities   CSVColumn = "qualities"
+	ColumnTraits      CSVColumn = "traits"
+	ColumnSources     CSVColumn = "sources"
+	ColumnNote        CSVColumn = "note"
+	ColumnComment     CSVColumn = "comment"
+)
+
+const defaultListSeparator = "; "
+
+var DefaultCSVColumns = []CSVColumn{
+	ColumnType, ColumnDisplayName, ColumnRoleTitle, ColumnStack, ColumnTraits, ColumnNote,
+}
+
+var csvColumnValues = map[CSVColumn]func(doc document.Document, sep string) string{
+	// в сокращённом режиме весь результат — заголовок
+	ColumnTitle: func(doc document.Document, _ string) string { return doc.Title.String() },
+	ColumnType: func(doc document.Document, _ string) string {
+		child, _ := findSection(doc, MsgChildhood)
+		return child.Attrs[document.AttrType]
+	},
+	ColumnDisplayName: func(doc document.Document, _ string) string {
+		return fieldValue(sectionBlocks(doc, MsgChildhood), MsgName).String()
+	},
+	ColumnDesiredRole: func(doc document.Document, _ string) string {
+		return fieldValue(sectionBlocks(doc, MsgChildhood), MsgRole).String()
+	},
+	ColumnRoleTitle: func(doc document.Document, _ string) string {
+		return fieldValue(sectionBlocks(doc, MsgAdult), MsgRole).String()
+	},
+	ColumnField: func(doc document.Document, _ string) string {
+		name, _ := splitField(fieldValue(sectionBlocks(doc, MsgAdult), MsgField))
+		return name
+	},
+	ColumnStack: func(doc document.Document, sep string) string {
+		return strings.Join(itemNames(findList(sectionBlocks(doc, MsgAdult), MsgStack)), sep)
+	},
+	ColumnQualities: func(doc document.Document, sep string) string {
+		return strings.Join(itemNames(findList(sectionBlocks(doc, MsgChildhood), MsgQualities)), sep)
+	},
+	ColumnTraits: func(doc document.Document, sep string) string {
+		return strings.Join(itemNames(findList(sectionBlocks(doc, MsgAdult), MsgTraits)), sep)
+	},
+	ColumnSources: func(doc document.Document, sep string) string {
+		return strings.Join(itemNames(findList(sectionBlocks(doc, MsgSources), MsgDreams)), sep)
+	},
+	ColumnNote: func(doc document.Document, _ string) string { return joinNotes(doc.Notes, " | ") },
+	ColumnComment: func(doc document.Document, _ string) string {
+		return findQuote(doc, MsgComment).String()
+	},
+}
+
+func CSVColumnNames() []string {
+	names := make([]string, 0, len(csvColumnValues))
+	for c := range csvColumnValues {
+		names = append(names, string(c))
+	}
+	sort.Strings(names)
+	return names
+}
+
+func ParseCSVColumns(s string) ([]CSVColumn, error) {
+	var columns []CSVColumn
+	for _, name := range strings.Split(s, ",") {
+		c := CSVColumn(strings.TrimSpace(name))
+		if _, ok := csvColumnValues[c]; !ok {
+			return nil, appErrors.NewValidationError(fmt.Sprintf("unknown csv column %q", c))
+		}
+		columns = append(columns, c)
+	}
+	return columns, nil
+}
+
+type CSVFormatter struct {
+	columns       []CSVColumn
+	delimiter     rune
+	listSeparator string
+	header        bool
+	// escapeFormulas: табличные редакторы исполняют ячейки, начинающиеся с =, +, -, @
+	escapeFormulas bool
+	catalog        Catalog
+}
+
+type CSVOption func(*CSVFormatter)
+
+func WithColumns(columns ...CSVColumn) CSVOption {
+	return func(f *CSVFormatter) {
+		f.columns = columns
+	}
+}
+
+func WithDelimiter(delimiter rune) CSVOption {
+	return func(f *CSVFormatter) {
+		f.delimiter = delimiter
+	}
+}
+
+func WithListSeparator(sep string) CSVOption {
+	return func(f *CSVFormatter) {
+		f.listSeparator = sep
+	}
+}
+
+func WithCSVCatalog(catalog Catalog) CSVOption {
+	return func(f *CSVFormatter) {
+		f.catalog = catalog
+	}
+}
+
+func WithoutHeader() CSVOption {
+	return func(f *CSVFormatter) {
+		f.header = false
+	}
+}
+
+func WithoutFormulaEscaping() CSVOption {
+	return func(f *CSVFormatter) {
+		f.escapeFormulas = false
+	}
+}
+
+func NewCSVFormatter(opts ...CSVOption) (*CSVFormatter, error) {
+	f := &CSVFormatter{
+		columns:        DefaultCSVColumns,
+		delimiter:      ',',
+		listSeparator:  defaultListSeparator,
+		header:         true,
+		escapeFormulas: true,
+	}
+	for _, opt := range opts {
+		opt(f)
+	}
+
+	if len(f.columns) == 0 {
+		return nil, appErrors.NewValidationError("csv formatter needs at least one column")
+	}
+	for _, c := range f.columns {
+		if _, ok := csvColumnValues[c]; !ok {
+			return nil, appErrors.NewValidationError(fmt.Sprintf("unknown csv column %q", c))
+		}
+	}
+	if f.delimiter == '"' || f.delimiter == '\r' || f.delimiter == '\n' || f.delimiter == 0 {
+		return nil, appErrors.NewValidationError(fmt.Sprintf("invalid csv delimiter %q", f.delimiter))
+	}
+	return f, nil
+}
+
+func NewTSVFormatter(opts ...CSVOption) (*CSVFormatter, error) {
+	return NewCSVFormatter(append([]CSVOption{WithDelimiter('\t')}, opts...)...)
+}
+
+func (f *CSVFormatter) Format(ctx context.Context, vm ports.ViewModel) (string, error) {
+	return f.FormatAll(ctx, []ports.ViewModel{vm})
+}
+
+func (f *CSVFormatter) FormatTo(ctx context.Context, w io.Writer, vm ports.ViewModel) error {
+	return f.FormatAllTo(ctx, w, []ports.ViewModel{vm})
+}
+
+func (f *CSVFormatter) FormatAll(ctx context.Context, vms []ports.ViewModel) (string, error) {
+	var buf bytes.Buffer
+	if err := f.FormatAllTo(ctx, &buf, vms); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func (f *CSVFormatter) FormatAllTo(ctx context.Context, w io.Writer, vms []ports.ViewModel) error {
+	sw := &streamWriter{w: w}
+	cw := csv.NewWriter(sw)
+	cw.Comma = f.delimiter
+
+	if f.header {
+		header := make([]string, len(f.columns))
+		for i, c := range f.columns {
+			header[i] = string(c)
+		}
+		if err := cw.Write(header); err != nil {
+			return f.writeError(sw, err, "write csv header")
+		}
+	}
+
+	record := make([]string, len(f.columns))
+	for _, vm := range vms {
+		if err := ctx.Err(); err != nil {
+			return appErrors.Wrap(err, appErrors.CodeInternal, "format csv rows")
+		}
+		doc := f.catalog.Localize(vm.Document())
+		for i, c := range f.columns {
+			record[i] = csvColumnValues[c](doc, f.listSeparator)
+			if f.escapeFormulas {
+				record[i] = escapeFormula(record[i])
+			}
+		}
+		if err := cw.Write(record); err != nil {
+			return f.writeError(sw, err, "write csv row")
+		}
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return f.writeError(sw, err, "flush csv")
+	}
+	return nil
+}
+
+func escapeFormula(cell string) string {
+	if cell != "" && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+		return "'" + cell
+	}
+	return cell
+}
+
+func (f *CSVFormatter) writeError(sw *streamWriter, err error, msg string) error {
+	if ioErr := sw.ioError(); ioErr != nil {
+		return ioErr
+	}
+	return appErrors.Wrap(err, appErrors.CodeInternal, msg)
+}
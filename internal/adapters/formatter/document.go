@@ -0,0 +1,90 @@
+package formatter
+
+import (
+	"strings"
+
+	"github.com/xeniasokk/field-switcher/pkg/document"
+)
+
+// Форматтеры со своей раскладкой ищут значения по ключам каталога; отсутствующий раздел даёт
+// пустое значение.
+
+func findSection(doc document.Document, key MessageKey) (document.Section, bool) {
+	for _, s := range doc.Sections {
+		if s.Key == string(key) {
+			return s, true
+		}
+	}
+	return document.Section{}, false
+}
+
+func sectionBlocks(doc document.Document, key MessageKey) []document.Block {
+	s, _ := findSection(doc, key)
+	return s.Blocks
+}
+
+func fieldValue(blocks []document.Block, label MessageKey) document.Text {
+	for _, block := range blocks {
+		fields, ok := block.(document.Fields)
+		if !ok {
+			continue
+		}
+		for _, field := range fields {
+			if field.Label == string(label) {
+				return field.Value
+			}
+		}
+	}
+	return nil
+}
+
+func findList(blocks []document.Block, label MessageKey) document.List {
+	for _, block := range blocks {
+		if list, ok := block.(document.List); ok && list.Label == string(label) {
+			return list
+		}
+	}
+	return document.List{}
+}
+
+// Окружение presenter кладёт отдельным фрагментом с подписью MsgEnvironment.
+func splitField(t document.Text) (name, environment string) {
+	var b strings.Builder
+	for _, s := range t {
+		if s.Label == string(MsgEnvironment) {
+			environment = s.Text
+			continue
+		}
+		b.WriteString(s.Text)
+	}
+	return b.String(), environment
+}
+
+func itemNames(list document.List) []string {
+	names := make([]string, 0, len(list.Items))
+	for _, item := range list.Items {
+		names = append(names, item.Name.String())
+	}
+	return names
+}
+
+func isHighlighted(t document.Text) bool {
+	return t.Emphasis() == document.EmphasisHighlight
+}
+
+func joinNotes(notes []document.Text, sep string) string {
+	parts := make([]string, 0, len(notes))
+	for _, note := range notes {
+		parts = append(parts, note.String())
+	}
+	return strings.Join(parts, sep)
+}
+
+func findQuote(doc document.Document, label MessageKey) document.Text {
+	for _, q := range doc.Quotes {
+		if q.Label == string(label) {
+			return q.Text
+		}
+	}
+	return nil
+}
@@ -0,0 +1,275 @@
+package formatter
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/xeniasokk/field-switcher/internal/ports"
+	appErrors "github.com/xeniasokk/field-switcher/pkg/errors"
+)
+
+var (
+	_ ports.FormatterPort      = (*GraphFormatter)(nil)
+	_ ports.BatchFormatterPort = (*GraphFormatter)(nil)
+)
+
+type GraphSyntax string
+
+const (
+	GraphDOT     GraphSyntax = "dot"
+	GraphMermaid GraphSyntax = "mermaid"
+)
+
+type graphNodeKind int
+
+const (
+	nodeDream graphNodeKind = iota
+	nodeQuality
+	nodeTrait
+	nodeRole
+	nodeStack
+)
+
+type graphNode struct {
+	id        string
+	kind      graphNodeKind
+	label     string
+	highlight bool
+}
+
+type graphEdge struct {
+	from, to string
+}
+
+// dreamGraph: в командном графе одинаковые узлы сливаются; слитый узел выделен, если выделен
+// хотя бы у одного участника.
+type dreamGraph struct {
+	nodes []graphNode
+	index map[string]int
+	edges []graphEdge
+	seen  map[graphEdge]bool
+	count map[graphNodeKind]int
+}
+
+func newDreamGraph(vms []ports.ViewModel) *dreamGraph {
+	g := &dreamGraph{
+		index: make(map[string]int),
+		seen:  make(map[graphEdge]bool),
+		count: make(map[graphNodeKind]int),
+	}
+	for _, vm := range vms {
+		doc := vm.Document()
+		child, adult := sectionBlocks(doc, MsgChildhood), sectionBlocks(doc, MsgAdult)
+		role := fieldValue(adult, MsgRole).String()
+
+		// мечта у каждого участника своя, даже если названия совпадают
+		label := fieldValue(child, MsgName).String() + "\n" + fieldValue(child, MsgRole).String()
+		dreamID := g.add(nodeDream, "", label, false)
+		roleID := g.add(nodeRole, role, role, false)
+
+		traits := make(map[string]string)
+		for _, t := range findList(adult, MsgTraits).Items {
+			name := t.Name.String()
+			id := g.add(nodeTrait, name, name, isHighlighted(t.Name))
+			traits[name] = id
+			g.link(id, roleID)
+		}
+		for _, q := range findList(child, MsgQualities).Items {
+			name := q.Name.String()
+			id := g.add(nodeQuality, name, name, isHighlighted(q.Name))
+			g.link(dreamID, id)
+			if traitID, ok := traits[name]; ok {
+				g.link(id, traitID)
+			}
+		}
+		for _, s := range findList(adult, MsgStack).Items {
+			name := s.Name.String()
+			g.link(roleID, g.add(nodeStack, name, name, isHighlighted(s.Name)))
+		}
+	}
+	return g
+}
+
+// Пустой key — узел не сливается с другими.
+func (g *dreamGraph) add(kind graphNodeKind, key, label string, highlight bool) string {
+	indexKey := fmt.Sprintf("%d:%s", kind, key)
+	if key != "" {
+		if i, ok := g.index[indexKey]; ok {
+			g.nodes[i].highlight = g.nodes[i].highlight || highlight
+			return g.nodes[i].id
+		}
+	}
+	g.count[kind]++
+	id := fmt.Sprintf("%s%d", [...]string{"dream", "q", "t", "role", "s"}[kind], g.count[kind])
+	if key != "" {
+		g.index[indexKey] = len(g.nodes)
+	}
+	g.nodes = append(g.nodes, graphNode{id: id, kind: kind, label: label, highlight: highlight})
+	return id
+}
+
+func (g *dreamGraph) link(from, to string) {
+	e := graphEdge{from: from, to: to}
+	if !g.seen[e] {
+		g.seen[e] = true
+		g.edges = append(g.edges, e)
+	}
+}
+
+type GraphFormatter struct {
+	syntax  GraphSyntax
+	catalog Catalog
+}
+
+type GraphOption func(*GraphFormatter)
+
+func WithGraphCatalog(catalog Catalog) GraphOption {
+	return func(f *GraphFormatter) {
+		f.catalog = catalog
+	}
+}
+
+func NewGraphFormatter(syntax GraphSyntax, opts ...GraphOption) (*GraphFormatter, error) {
+	if syntax != GraphDOT && syntax != GraphMermaid {
+		return nil, appErrors.NewValidationError(fmt.Sprintf("unknown graph syntax %q", syntax))
+	}
+	f := &GraphFormatter{syntax: syntax}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f, nil
+}
+
+func (f *GraphFormatter) Format(ctx context.Context, vm ports.ViewModel) (string, error) {
+	return f.FormatAll(ctx, []ports.ViewModel{vm})
+}
+
+func (f *GraphFormatter) FormatAll(ctx context.Context, vms []ports.ViewModel) (string, error) {
+	_ = ctx
+
+	g := newDreamGraph(vms)
+	var b strings.Builder
+	if f.syntax == GraphMermaid {
+		f.writeMermaid(&b, g)
+	} else {
+		f.writeDOT(&b, g)
+	}
+	return b.String(), nil
+}
+
+func (f *GraphFormatter) writeDOT(b *strings.Builder, g *dreamGraph) {
+	b.WriteString("digraph dreams {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [fontname=\"DejaVu Sans\", fontsize=11];\n")
+	b.WriteString("  edge [color=\"#8b9098\"];\n")
+
+	_, _ = fmt.Fprintf(b, "  subgraph cluster_childhood {\n    label=%s;\n    color=\"#e5c07b\";\n", dotQuote(f.catalog.T(MsgChildhood)))
+	for _, n := range g.nodes {
+		if n.kind == nodeDream || n.kind == nodeQuality {
+			_, _ = fmt.Fprintf(b, "    %s;\n", dotNode(n))
+		}
+	}
+	b.WriteString("  }\n")
+
+	_, _ = fmt.Fprintf(b, "  subgraph cluster_adult {\n    label=%s;\n    color=\"#98c379\";\n", dotQuote(f.catalog.T(MsgAdult)))
+	for _, n := range g.nodes {
+		if n.kind == nodeTrait || n.kind == nodeRole {
+			_, _ = fmt.Fprintf(b, "    %s;\n", dotNode(n))
+		}
+	}
+	b.WriteString("  }\n")
+
+	_, _ = fmt.Fprintf(b, "  subgraph cluster_stack {\n    label=%s;\n    color=\"#d19ad8\";\n", dotQuote(f.catalog.T(MsgStack)))
+	for _, n := range g.nodes {
+		if n.kind == nodeStack {
+			_, _ = fmt.Fprintf(b, "    %s;\n", dotNode(n))
+		}
+	}
+	b.WriteString("  }\n")
+
+	for _, e := range g.edges {
+		_, _ = fmt.Fprintf(b, "  %s -> %s;\n", e.from, e.to)
+	}
+	b.WriteString("}\n")
+}
+
+func dotNode(n graphNode) string {
+	attrs := map[graphNodeKind]string{
+		nodeDream:   `shape=box, style="rounded,filled", fillcolor="#e5c07b"`,
+		nodeQuality: `shape=ellipse`,
+		nodeTrait:   `shape=ellipse, style=filled, fillcolor="#d8ecd0"`,
+		nodeRole:    `shape=box, style="rounded,filled", fillcolor="#98c379"`,
+		nodeStack:   `shape=note, color="#d19ad8"`,
+	}[n.kind]
+	if n.highlight {
+		attrs += `, color="#ff6b6b", penwidth=2`
+	}
+	return fmt.Sprintf("%s [label=%s, %s]", n.id, dotQuote(n.label), attrs)
+}
+
+var dotEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func dotQuote(s string) string {
+	return `"` + dotEscaper.Replace(s) + `"`
+}
+
+func (f *GraphFormatter) writeMermaid(b *strings.Builder, g *dreamGraph) {
+	b.WriteString("flowchart LR\n")
+
+	groups := []struct {
+		id    string
+		label MessageKey
+		kinds []graphNodeKind
+	}{
+		{"childhood", MsgChildhood, []graphNodeKind{nodeDream, nodeQuality}},
+		{"adult", MsgAdult, []graphNodeKind{nodeTrait, nodeRole}},
+		{"stack", MsgStack, []graphNodeKind{nodeStack}},
+	}
+	for _, group := range groups {
+		_, _ = fmt.Fprintf(b, "  subgraph %s[\"%s\"]\n", group.id, mermaidEscape(f.catalog.T(group.label)))
+		for _, n := range g.nodes {
+			for _, k := range group.kinds {
+				if n.kind == k {
+					_, _ = fmt.Fprintf(b, "    %s\n", mermaidNode(n))
+				}
+			}
+		}
+		b.WriteString("  end\n")
+	}
+
+	for _, e := range g.edges {
+		_, _ = fmt.Fprintf(b, "  %s --> %s\n", e.from, e.to)
+	}
+
+	var highlighted []string
+	for _, n := range g.nodes {
+		if n.highlight {
+			highlighted = append(highlighted, n.id)
+		}
+	}
+	if len(highlighted) > 0 {
+		b.WriteString("  classDef highlight stroke:#ff6b6b,stroke-width:3px\n")
+		_, _ = fmt.Fprintf(b, "  class %s highlight\n", strings.Join(highlighted, ","))
+	}
+}
+
+func mermaidNode(n graphNode) string {
+	label := `"` + mermaidEscape(n.label) + `"`
+	switch n.kind {
+	case nodeDream, nodeRole:
+		return n.id + "(" + label + ")"
+	case nodeStack:
+		return n.id + "[/" + label + "/]"
+	default:
+		return n.id + "([" + label + "])"
+	}
+}
+
+var mermaidEscaper = strings.NewReplacer(
+	"#", "#35;", `"`, "#quot;", "<", "#lt;", ">", "#gt;", "\n", "<br/>",
+)
+
+func mermaidEscape(s string) string {
+	return mermaidEscaper.Replace(s)
+}
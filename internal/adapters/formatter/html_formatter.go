@@ -0,0 +1,126 @@
+package formatter
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"html/template"
+	"strings"
+
+	"github.com/xeniasokk/field-switcher/internal/ports"
+	"github.com/xeniasokk/field-switcher/pkg/document"
+	appErrors "github.com/xeniasokk/field-switcher/pkg/errors"
+)
+
+var _ ports.FormatterPort = (*HTMLFormatter)(nil)
+
+//go:embed templates/report.html.tmpl
+var htmlReportTemplate string
+
+type HTMLTheme struct {
+	Name        string
+	Background  template.CSS
+	Foreground  template.CSS
+	Muted       template.CSS
+	Card        template.CSS
+	Title       template.CSS
+	Childhood   template.CSS
+	Adult       template.CSS
+	Persistence template.CSS
+	Stack       template.CSS
+	Note        template.CSS
+}
+
+// Цвета как в colorScheme TextFormatter.
+var (
+	HTMLThemeDark = HTMLTheme{
+		Name:        "dark",
+		Background:  "#14161a",
+		Foreground:  "#e6e6e6",
+		Muted:       "#8b9098",
+		Card:        "#1e2127",
+		Title:       "#4fd1e8",
+		Childhood:   "#e5c07b",
+		Adult:       "#98c379",
+		Persistence: "#ff6b6b",
+		Stack:       "#d19ad8",
+		Note:        "#7aa2f7",
+	}
+	HTMLThemeLight = HTMLTheme{
+		Name:        "light",
+		Background:  "#f6f7f9",
+		Foreground:  "#1f2328",
+		Muted:       "#656d76",
+		Card:        "#ffffff",
+		Title:       "#0a7ea4",
+		Childhood:   "#9a6700",
+		Adult:       "#1a7f37",
+		Persistence: "#cf222e",
+		Stack:       "#8250df",
+		Note:        "#0550ae",
+	}
+)
+
+type HTMLFormatter struct {
+	theme   HTMLTheme
+	catalog Catalog
+	tmpl    *template.Template
+}
+
+type HTMLOption func(*HTMLFormatter)
+
+func WithHTMLTheme(theme HTMLTheme) HTMLOption {
+	return func(f *HTMLFormatter) {
+		f.theme = theme
+	}
+}
+
+func WithHTMLCatalog(catalog Catalog) HTMLOption {
+	return func(f *HTMLFormatter) {
+		f.catalog = catalog
+	}
+}
+
+func NewHTMLFormatter(opts ...HTMLOption) (*HTMLFormatter, error) {
+	f := &HTMLFormatter{
+		theme: HTMLThemeDark,
+	}
+	for _, opt := range opts {
+		opt(f)
+	}
+
+	tmpl, err := template.New("report").Funcs(template.FuncMap{
+		"isFields": func(b document.Block) bool { _, ok := b.(document.Fields); return ok },
+		"join":     strings.Join,
+		"t":        func(key string) string { return f.catalog.T(MessageKey(key)) },
+		"label":    func(key string) string { return f.catalog.Label(MessageKey(key)) },
+		"heading":  func(key string) string { return f.catalog.Heading(MessageKey(key)) },
+	}).Parse(htmlReportTemplate)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.CodeInternal, "parse HTML report template")
+	}
+	f.tmpl = tmpl
+	return f, nil
+}
+
+type htmlReport struct {
+	Lang  Locale
+	Theme HTMLTheme
+	Doc   document.Document
+}
+
+func (f *HTMLFormatter) Format(ctx context.Context, vm ports.ViewModel) (string, error) {
+	_ = ctx
+
+	report := htmlReport{
+		Lang:  f.catalog.Locale(),
+		Theme: f.theme,
+		Doc:   f.catalog.Localize(vm.Document()),
+	}
+
+	var buf bytes.Buffer
+	if err := f.tmpl.Execute(&buf, report); err != nil {
+		return "", appErrors.Wrap(err, appErrors.CodeInternal, "render HTML report")
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,169 @@
+package formatter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/xeniasokk/field-switcher/internal/ports"
+	"github.com/xeniasokk/field-switcher/pkg/document"
+	appErrors "github.com/xeniasokk/field-switcher/pkg/errors"
+)
+
+var _ ports.StreamFormatterPort = (*JSONFormatter)(nil)
+
+// JSONSchemaVersion меняется при любом несовместимом изменении схемы (docs/schema/view-model.v1.json).
+const JSONSchemaVersion = "1"
+
+// JSONDocument — схема v1. Разделы, которые режим представления не выводит, опускаются.
+type JSONDocument struct {
+	SchemaVersion string          `json:"schema_version"`
+	Title         string          `json:"title"`
+	Childhood     *JSONChildhood  `json:"childhood,omitempty"`
+	Sources       []JSONChildhood `json:"sources,omitempty"`
+	Adult         *JSONAdult      `json:"adult,omitempty"`
+	Note          string          `json:"note,omitempty"`
+	Comment       string          `json:"comment,omitempty"`
+}
+
+type JSONChildhood struct {
+	Type        string        `json:"type"`
+	DisplayName string        `json:"display_name"`
+	DesiredRole string        `json:"desired_role"`
+	Field       JSONField     `json:"field"`
+	Qualities   []JSONQuality `json:"qualities"`
+}
+
+type JSONAdult struct {
+	RoleTitle       string        `json:"role_title"`
+	RoleDescription string        `json:"role_description,omitempty"`
+	Field           *JSONField    `json:"field,omitempty"`
+	Stack           []string      `json:"stack"`
+	Traits          []JSONQuality `json:"traits"`
+}
+
+type JSONField struct {
+	Name        string `json:"name"`
+	Environment string `json:"environment"`
+}
+
+type JSONQuality struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Intensity   int      `json:"intensity,omitempty"`
+	Sources     []string `json:"sources,omitempty"`
+	// пишется и false: строгие шаблоны обращаются к полю без проверки
+	Highlighted bool `json:"highlighted"`
+}
+
+type JSONFormatter struct {
+	indent  string
+	catalog Catalog
+}
+
+type JSONOption func(*JSONFormatter)
+
+func WithPrettyPrint(indent string) JSONOption {
+	return func(f *JSONFormatter) {
+		f.indent = indent
+	}
+}
+
+func WithJSONCatalog(catalog Catalog) JSONOption {
+	return func(f *JSONFormatter) {
+		f.catalog = catalog
+	}
+}
+
+func NewJSONFormatter(opts ...JSONOption) *JSONFormatter {
+	f := &JSONFormatter{}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
+}
+
+func (f *JSONFormatter) Format(ctx context.Context, vm ports.ViewModel) (string, error) {
+	var buf bytes.Buffer
+	if err := f.FormatTo(ctx, &buf, vm); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func (f *JSONFormatter) FormatTo(ctx context.Context, w io.Writer, vm ports.ViewModel) error {
+	_ = ctx
+
+	sw := &streamWriter{w: w}
+	enc := json.NewEncoder(sw)
+	enc.SetEscapeHTML(false)
+	if f.indent != "" {
+		enc.SetIndent("", f.indent)
+	}
+	if err := enc.Encode(NewJSONDocument(f.catalog.Localize(vm.Document()))); err != nil {
+		if ioErr := sw.ioError(); ioErr != nil {
+			return ioErr
+		}
+		return appErrors.Wrap(err, appErrors.CodeInternal, "encode view model as JSON")
+	}
+	return nil
+}
+
+func NewJSONDocument(doc document.Document) JSONDocument {
+	out := JSONDocument{
+		SchemaVersion: JSONSchemaVersion,
+		Title:         doc.Title.String(),
+		Note:          joinNotes(doc.Notes, " | "),
+		Comment:       findQuote(doc, MsgComment).String(),
+	}
+	if child, ok := findSection(doc, MsgChildhood); ok {
+		c := jsonChildhood(child.Attrs, child.Blocks)
+		out.Childhood = &c
+	}
+	if adult, ok := findSection(doc, MsgAdult); ok {
+		out.Adult = &JSONAdult{
+			RoleTitle:       fieldValue(adult.Blocks, MsgRole).String(),
+			RoleDescription: fieldValue(adult.Blocks, MsgDescription).String(),
+			Stack:           itemNames(findList(adult.Blocks, MsgStack)),
+			Traits:          jsonQualities(findList(adult.Blocks, MsgTraits)),
+		}
+		if field := fieldValue(adult.Blocks, MsgField); field != nil {
+			f := jsonField(field)
+			out.Adult.Field = &f
+		}
+	}
+	for _, item := range findList(sectionBlocks(doc, MsgSources), MsgDreams).Items {
+		out.Sources = append(out.Sources, jsonChildhood(item.Attrs, item.Blocks))
+	}
+	return out
+}
+
+func jsonChildhood(attrs map[string]string, blocks []document.Block) JSONChildhood {
+	return JSONChildhood{
+		Type:        attrs[document.AttrType],
+		DisplayName: fieldValue(blocks, MsgName).String(),
+		DesiredRole: fieldValue(blocks, MsgRole).String(),
+		Field:       jsonField(fieldValue(blocks, MsgField)),
+		Qualities:   jsonQualities(findList(blocks, MsgQualities)),
+	}
+}
+
+func jsonField(t document.Text) JSONField {
+	name, env := splitField(t)
+	return JSONField{Name: name, Environment: env}
+}
+
+func jsonQualities(list document.List) []JSONQuality {
+	out := make([]JSONQuality, 0, len(list.Items))
+	for _, item := range list.Items {
+		out = append(out, JSONQuality{
+			Name:        item.Name.String(),
+			Description: item.Detail.String(),
+			Intensity:   item.Weight,
+			Sources:     item.Refs,
+			Highlighted: isHighlighted(item.Name),
+		})
+	}
+	return out
+}
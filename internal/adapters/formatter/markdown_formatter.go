@@ -0,0 +1,195 @@
+package formatter
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/xeniasokk/field-switcher/internal/ports"
+	"github.com/xeniasokk/field-switcher/pkg/document"
+)
+
+var _ ports.FormatterPort = (*MarkdownFormatter)(nil)
+
+type MarkdownFormatter struct {
+	frontMatter bool
+	catalog     Catalog
+}
+
+type MarkdownOption func(*MarkdownFormatter)
+
+func WithFrontMatter() MarkdownOption {
+	return func(f *MarkdownFormatter) {
+		f.frontMatter = true
+	}
+}
+
+func WithMarkdownCatalog(catalog Catalog) MarkdownOption {
+	return func(f *MarkdownFormatter) {
+		f.catalog = catalog
+	}
+}
+
+func NewMarkdownFormatter(opts ...MarkdownOption) *MarkdownFormatter {
+	f := &MarkdownFormatter{}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
+}
+
+func (f *MarkdownFormatter) Format(ctx context.Context, vm ports.ViewModel) (string, error) {
+	_ = ctx
+
+	var b strings.Builder
+	doc := f.catalog.Localize(vm.Document())
+	if f.frontMatter {
+		f.WriteFrontMatter(&b, doc)
+	}
+	if title := doc.Title.String(); title != "" {
+		_, _ = fmt.Fprintf(&b, "# %s\n\n", mdEscape(title))
+	}
+	for _, section := range doc.Sections {
+		f.WriteSection(&b, section)
+	}
+	if len(doc.Notes) > 0 {
+		notes := make([]string, 0, len(doc.Notes))
+		for _, note := range doc.Notes {
+			notes = append(notes, mdText(note))
+		}
+		_, _ = fmt.Fprintf(&b, "_%s_\n\n", strings.Join(notes, ` \| `))
+	}
+	for _, quote := range doc.Quotes {
+		if quote.Label != "" {
+			_, _ = fmt.Fprintf(&b, "## %s\n\n", f.catalog.T(MessageKey(quote.Label)))
+		}
+		for _, line := range strings.Split(quote.Text.String(), "\n") {
+			if line == "" {
+				b.WriteString(">\n")
+				continue
+			}
+			_, _ = fmt.Fprintf(&b, "> %s\n", mdEscape(line))
+		}
+		b.WriteString("\n")
+	}
+
+	return strings.TrimRight(b.String(), "\n") + "\n", nil
+}
+
+func (f *MarkdownFormatter) WriteFrontMatter(b *strings.Builder, doc document.Document) {
+	child, _ := findSection(doc, MsgChildhood)
+	adult := sectionBlocks(doc, MsgAdult)
+	b.WriteString("---\n")
+	_, _ = fmt.Fprintf(b, "title: %s\n", yamlQuote(doc.Title.String()))
+	_, _ = fmt.Fprintf(b, "dream_type: %s\n", yamlQuote(child.Attrs[document.AttrType]))
+	_, _ = fmt.Fprintf(b, "dream: %s\n", yamlQuote(fieldValue(child.Blocks, MsgName).String()))
+	_, _ = fmt.Fprintf(b, "role: %s\n", yamlQuote(fieldValue(adult, MsgRole).String()))
+	if stack := itemNames(findList(adult, MsgStack)); len(stack) > 0 {
+		b.WriteString("stack:\n")
+		for _, s := range stack {
+			_, _ = fmt.Fprintf(b, "  - %s\n", yamlQuote(s))
+		}
+	}
+	b.WriteString("---\n\n")
+}
+
+func (f *MarkdownFormatter) WriteSection(b *strings.Builder, section document.Section) {
+	_, _ = fmt.Fprintf(b, "## %s\n\n", f.catalog.T(MessageKey(section.Key)))
+	for _, block := range section.Blocks {
+		switch block := block.(type) {
+		case document.Fields:
+			for _, field := range block {
+				_, _ = fmt.Fprintf(b, "- **%s** %s\n", f.catalog.Label(MessageKey(field.Label)), mdText(field.Value))
+			}
+			b.WriteString("\n")
+		case document.List:
+			if len(block.Items) == 0 {
+				continue
+			}
+			if block.Label != "" {
+				_, _ = fmt.Fprintf(b, "### %s\n\n", f.catalog.T(MessageKey(block.Label)))
+			}
+			for _, item := range block.Items {
+				b.WriteString("- " + mdText(item.Name))
+				if len(item.Detail) > 0 {
+					b.WriteString(" — " + mdText(item.Detail))
+				}
+				if len(item.Refs) > 0 {
+					_, _ = fmt.Fprintf(b, " _(%s)_", mdEscape(strings.Join(item.Refs, ", ")))
+				}
+				b.WriteString("\n")
+			}
+			b.WriteString("\n")
+		}
+	}
+}
+
+func mdText(t document.Text) string {
+	var b strings.Builder
+	for _, s := range t {
+		if s.Label != "" {
+			b.WriteString(" (" + mdEscape(s.Text) + ")")
+			continue
+		}
+		switch s.Emphasis {
+		case document.EmphasisHighlight:
+			b.WriteString("**" + mdEscape(s.Text) + "**")
+		case document.EmphasisCode:
+			b.WriteString(mdCode(s.Text))
+		default:
+			b.WriteString(mdEscape(s.Text))
+		}
+	}
+	return b.String()
+}
+
+// mdEscape экранирует символы разметки во внутристрочном тексте и маркеры блоков в начале строки.
+func mdEscape(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		switch r {
+		case '\\', '`', '*', '_', '[', ']', '<', '>', '|', '~', '!', '&':
+			b.WriteByte('\\')
+		case '#', '+', '-', '=':
+			if i == 0 {
+				b.WriteByte('\\')
+			}
+		case '.', ')':
+			if i > 0 && isDigits(s[:i]) {
+				b.WriteByte('\\')
+			}
+		case '\n':
+			b.WriteString(" ")
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// mdCode подбирает ограничитель длиннее любой серии обратных кавычек внутри.
+func mdCode(s string) string {
+	longest, run := 0, 0
+	for _, r := range s {
+		if r == '`' {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	fence := strings.Repeat("`", longest+1)
+	if strings.HasPrefix(s, "`") || strings.HasSuffix(s, "`") {
+		s = " " + s + " "
+	}
+	return fence + s + fence
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
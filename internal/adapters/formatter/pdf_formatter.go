@@ -0,0 +1,271 @@
+package formatter
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goitalic"
+	"golang.org/x/image/font/gofont/goregular"
+
+	"github.com/xeniasokk/field-switcher/internal/ports"
+	"github.com/xeniasokk/field-switcher/pkg/document"
+	appErrors "github.com/xeniasokk/field-switcher/pkg/errors"
+)
+
+var (
+	_ ports.FormatterPort       = (*PDFFormatter)(nil)
+	_ ports.StreamFormatterPort = (*PDFFormatter)(nil)
+)
+
+// Размеры в миллиметрах, страница A4 книжная.
+const (
+	pdfPageWidth     = 210.0
+	pdfPageHeight    = 297.0
+	pdfFrame         = 10.0
+	pdfContentMargin = 30.0
+	pdfContentWidth  = pdfPageWidth - 2*pdfContentMargin
+	pdfCommentLines  = 6
+	pdfContentTop    = 36.0
+	pdfFooterGap     = 4.0
+	pdfArrowLength   = 18.0
+
+	pdfFontFamily = "Go"
+)
+
+type pdfColor struct{ r, g, b int }
+
+var (
+	pdfInk    = pdfColor{0x22, 0x26, 0x2e}
+	pdfMuted  = pdfColor{0x6b, 0x70, 0x78}
+	pdfGold   = pdfColor{0xb8, 0x8a, 0x2c}
+	pdfGreen  = pdfColor{0x3f, 0x7d, 0x3a}
+	pdfAccent = pdfColor{0xc0, 0x39, 0x2b}
+)
+
+// PDFFormatter встраивает шрифты Go, чтобы кириллица читалась в любом просмотрщике.
+type PDFFormatter struct {
+	catalog  Catalog
+	issuedAt time.Time
+}
+
+type PDFOption func(*PDFFormatter)
+
+func WithPDFCatalog(catalog Catalog) PDFOption {
+	return func(f *PDFFormatter) {
+		f.catalog = catalog
+	}
+}
+
+// WithIssuedAt фиксирует и даты в метаданных, так что вывод побайтно повторяем.
+func WithIssuedAt(t time.Time) PDFOption {
+	return func(f *PDFFormatter) {
+		f.issuedAt = t
+	}
+}
+
+func NewPDFFormatter(opts ...PDFOption) *PDFFormatter {
+	f := &PDFFormatter{}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
+}
+
+func (f *PDFFormatter) Format(ctx context.Context, vm ports.ViewModel) (string, error) {
+	var buf bytes.Buffer
+	if err := f.FormatTo(ctx, &buf, vm); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func (f *PDFFormatter) FormatTo(ctx context.Context, w io.Writer, vm ports.ViewModel) error {
+	_ = ctx
+
+	doc := f.catalog.Localize(vm.Document())
+	pdf := f.newDocument(doc)
+	f.writeFrame(pdf)
+	f.writeCertificate(pdf, doc)
+
+	sw := &streamWriter{w: w}
+	if err := pdf.Output(sw); err != nil {
+		if ioErr := sw.ioError(); ioErr != nil {
+			return ioErr
+		}
+		return appErrors.Wrap(err, appErrors.CodeInternal, "render pdf certificate")
+	}
+	return nil
+}
+
+func (f *PDFFormatter) newDocument(doc document.Document) *fpdf.Fpdf {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetCatalogSort(true)
+	if !f.issuedAt.IsZero() {
+		pdf.SetCreationDate(f.issuedAt)
+		pdf.SetModificationDate(f.issuedAt)
+	}
+	pdf.SetTitle(f.catalog.T(MsgCertificate)+": "+fieldValue(sectionBlocks(doc, MsgAdult), MsgRole).String(), true)
+	pdf.SetLang(string(f.catalog.Locale()))
+	pdf.SetAutoPageBreak(false, 0)
+
+	pdf.AddUTF8FontFromBytes(pdfFontFamily, "", goregular.TTF)
+	pdf.AddUTF8FontFromBytes(pdfFontFamily, "B", gobold.TTF)
+	pdf.AddUTF8FontFromBytes(pdfFontFamily, "I", goitalic.TTF)
+	pdf.AddPage()
+	return pdf
+}
+
+func (f *PDFFormatter) writeFrame(pdf *fpdf.Fpdf) {
+	pdf.SetDrawColor(pdfGold.r, pdfGold.g, pdfGold.b)
+	pdf.SetLineWidth(1.2)
+	pdf.Rect(pdfFrame, pdfFrame, pdfPageWidth-2*pdfFrame, pdfPageHeight-2*pdfFrame, "D")
+	pdf.SetLineWidth(0.3)
+	pdf.Rect(pdfFrame+4, pdfFrame+4, pdfPageWidth-2*pdfFrame-8, pdfPageHeight-2*pdfFrame-8, "D")
+}
+
+func (f *PDFFormatter) writeCertificate(pdf *fpdf.Fpdf, doc document.Document) {
+	child, adult := sectionBlocks(doc, MsgChildhood), sectionBlocks(doc, MsgAdult)
+
+	blocks := []pdfBlock{
+		pdfText(pdf, 0, "B", 30, 13, pdfGold, f.catalog.Heading(MsgCertificate)),
+		pdfText(pdf, 0, "I", 16, 10, pdfInk, f.catalog.T(MsgStillInGame)),
+		pdfText(pdf, 12, "", 11, 6, pdfMuted, f.catalog.T(MsgChildhood)),
+		pdfText(pdf, 0, "B", 20, 9, pdfInk, fieldValue(child, MsgName).String()),
+		pdfText(pdf, 0, "", 14, 7, pdfInk, fieldValue(child, MsgRole).String()),
+	}
+	if qualities := itemNames(findList(child, MsgQualities)); len(qualities) > 0 {
+		blocks = append(blocks, pdfText(pdf, 2, "", 11, 5.5, pdfMuted, strings.Join(qualities, " · ")))
+	}
+	blocks = append(blocks,
+		pdfBlock{gap: 5, arrow: true},
+		pdfText(pdf, 0, "", 11, 6, pdfMuted, f.catalog.T(MsgAdult)),
+		pdfText(pdf, 0, "B", 24, 11, pdfGreen, fieldValue(adult, MsgRole).String()),
+		pdfText(pdf, 0, "", 12, 6, pdfInk, pdfField(fieldValue(adult, MsgField))),
+	)
+	if stack := itemNames(findList(adult, MsgStack)); len(stack) > 0 {
+		blocks = append(blocks,
+			pdfText(pdf, 8, "", 11, 6, pdfMuted, f.catalog.T(MsgStack)),
+			pdfText(pdf, 0, "B", 12, 6, pdfInk, strings.Join(stack, " · ")),
+		)
+	}
+	if comment := findQuote(doc, MsgComment).String(); comment != "" {
+		quote := pdfText(pdf, 0, "I", 12, 6, pdfAccent, comment)
+		pdfTruncate(pdf, &quote, pdfCommentLines)
+		blocks = append(blocks, pdfText(pdf, 8, "", 11, 6, pdfMuted, f.catalog.T(MsgComment)), quote)
+	}
+
+	footerY := pdfPageHeight - pdfFrame - 22
+	pdfFit(pdf, blocks, footerY-pdfFooterGap-pdfContentTop)
+
+	pdf.SetY(pdfContentTop)
+	for _, b := range blocks {
+		b.write(pdf)
+	}
+
+	pdf.SetY(footerY)
+	if title := doc.Title.String(); title != "" {
+		pdfCentered(pdf, "", 10, 5, pdfMuted, title)
+	}
+	if !f.issuedAt.IsZero() {
+		pdfCentered(pdf, "", 10, 5, pdfMuted, f.catalog.Tf(MsgIssued, f.issuedAt.Format("02.01.2006")))
+	}
+}
+
+// pdfBlock разбит на строки заранее, чтобы высоту можно было измерить до отрисовки.
+type pdfBlock struct {
+	gap        float64
+	style      string
+	size       float64
+	lineHeight float64
+	color      pdfColor
+	lines      []string
+	arrow      bool
+}
+
+func pdfText(pdf *fpdf.Fpdf, gap float64, style string, size, lineHeight float64, c pdfColor, text string) pdfBlock {
+	pdf.SetFont(pdfFontFamily, style, size)
+	var lines []string
+	if text != "" {
+		lines = pdf.SplitText(text, pdfContentWidth)
+	}
+	return pdfBlock{gap: gap, style: style, size: size, lineHeight: lineHeight, color: c, lines: lines}
+}
+
+func (b pdfBlock) height() float64 {
+	if b.arrow {
+		return b.gap + pdfArrowLength + 4
+	}
+	return b.gap + float64(len(b.lines))*b.lineHeight
+}
+
+func (b pdfBlock) write(pdf *fpdf.Fpdf) {
+	if b.arrow {
+		pdfArrow(pdf, pdf.GetY()+b.gap, pdfArrowLength)
+		return
+	}
+	pdf.SetY(pdf.GetY() + b.gap)
+	pdf.SetFont(pdfFontFamily, b.style, b.size)
+	pdf.SetTextColor(b.color.r, b.color.g, b.color.b)
+	for _, line := range b.lines {
+		pdf.SetX(pdfContentMargin)
+		pdf.CellFormat(pdfContentWidth, b.lineHeight, line, "", 2, "C", false, 0, "")
+	}
+}
+
+// pdfFit укорачивает абзацы снизу вверх, пока содержимое не поместится в height.
+func pdfFit(pdf *fpdf.Fpdf, blocks []pdfBlock, height float64) {
+	total := 0.0
+	for _, b := range blocks {
+		total += b.height()
+	}
+	for i := len(blocks) - 1; i >= 0 && total > height; i-- {
+		b := &blocks[i]
+		for len(b.lines) > 1 && total > height {
+			pdfTruncate(pdf, b, len(b.lines)-1)
+			total -= b.lineHeight
+		}
+	}
+}
+
+func pdfTruncate(pdf *fpdf.Fpdf, b *pdfBlock, lines int) {
+	if len(b.lines) <= lines {
+		return
+	}
+	pdf.SetFont(pdfFontFamily, b.style, b.size)
+	last := []rune(strings.TrimSpace(b.lines[lines-1]))
+	limit := pdfContentWidth - 2*pdf.GetCellMargin()
+	for len(last) > 0 && pdf.GetStringWidth(string(last)+"…") > limit {
+		last = last[:len(last)-1]
+	}
+	b.lines = append(b.lines[:lines-1], string(last)+"…")
+}
+
+func pdfCentered(pdf *fpdf.Fpdf, style string, size, lineHeight float64, c pdfColor, text string) {
+	pdf.SetFont(pdfFontFamily, style, size)
+	pdf.SetTextColor(c.r, c.g, c.b)
+	pdf.SetX(pdfContentMargin)
+	pdf.MultiCell(pdfContentWidth, lineHeight, text, "", "C", false)
+}
+
+func pdfArrow(pdf *fpdf.Fpdf, y, length float64) {
+	x := pdfPageWidth / 2
+	pdf.SetDrawColor(pdfGold.r, pdfGold.g, pdfGold.b)
+	pdf.SetFillColor(pdfGold.r, pdfGold.g, pdfGold.b)
+	pdf.SetLineWidth(0.8)
+	pdf.Line(x, y, x, y+length-3)
+	pdf.Polygon([]fpdf.PointType{{X: x - 3, Y: y + length - 4}, {X: x + 3, Y: y + length - 4}, {X: x, Y: y + length}}, "F")
+	pdf.SetY(y + length + 4)
+}
+
+func pdfField(t document.Text) string {
+	name, env := splitField(t)
+	if env == "" {
+		return name
+	}
+	return name + " — " + env
+}
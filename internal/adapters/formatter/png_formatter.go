@@ -0,0 +1,309 @@
+package formatter
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"strings"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+
+	"github.com/xeniasokk/field-switcher/internal/ports"
+	"github.com/xeniasokk/field-switcher/pkg/document"
+	appErrors "github.com/xeniasokk/field-switcher/pkg/errors"
+)
+
+var (
+	_ ports.FormatterPort       = (*PNGFormatter)(nil)
+	_ ports.StreamFormatterPort = (*PNGFormatter)(nil)
+)
+
+// 1200×630 — размер превью ссылок в мессенджерах и соцсетях.
+const (
+	pngWidth     = 1200
+	pngHeight    = 630
+	pngMargin    = 64
+	pngBadgeGap  = 14
+	pngBadgePad  = 20
+	pngBadgeSize = 26
+)
+
+var (
+	pngGradientFrom = color.RGBA{0x14, 0x16, 0x1a, 0xff}
+	pngGradientTo   = color.RGBA{0x1f, 0x33, 0x3a, 0xff}
+	pngText         = color.RGBA{0xe6, 0xe6, 0xe6, 0xff}
+	pngMuted        = color.RGBA{0x8b, 0x90, 0x98, 0xff}
+	pngGold         = color.RGBA{0xe5, 0xc0, 0x7b, 0xff}
+	pngGreen        = color.RGBA{0x98, 0xc3, 0x79, 0xff}
+	pngBadge        = color.RGBA{0x4f, 0xd1, 0xe8, 0xff}
+	pngPersistence  = color.RGBA{0xff, 0x6b, 0x6b, 0xff}
+)
+
+// PNGFormatter рисует целочисленно встроенными шрифтами, так что вывод совпадает попиксельно.
+type PNGFormatter struct {
+	catalog Catalog
+	regular *opentype.Font
+	bold    *opentype.Font
+}
+
+type PNGOption func(*PNGFormatter)
+
+func WithPNGCatalog(catalog Catalog) PNGOption {
+	return func(f *PNGFormatter) {
+		f.catalog = catalog
+	}
+}
+
+func NewPNGFormatter(opts ...PNGOption) (*PNGFormatter, error) {
+	regular, err := opentype.Parse(goregular.TTF)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.CodeInternal, "parse regular font")
+	}
+	bold, err := opentype.Parse(gobold.TTF)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.CodeInternal, "parse bold font")
+	}
+
+	f := &PNGFormatter{regular: regular, bold: bold}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f, nil
+}
+
+func (f *PNGFormatter) Format(ctx context.Context, vm ports.ViewModel) (string, error) {
+	var buf bytes.Buffer
+	if err := f.FormatTo(ctx, &buf, vm); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func (f *PNGFormatter) FormatTo(ctx context.Context, w io.Writer, vm ports.ViewModel) error {
+	_ = ctx
+
+	img, err := f.render(f.catalog.Localize(vm.Document()))
+	if err != nil {
+		return err
+	}
+	sw := &streamWriter{w: w}
+	if err := png.Encode(sw, img); err != nil {
+		if ioErr := sw.ioError(); ioErr != nil {
+			return ioErr
+		}
+		return appErrors.Wrap(err, appErrors.CodeInternal, "encode png card")
+	}
+	return nil
+}
+
+func (f *PNGFormatter) render(doc document.Document) (*image.RGBA, error) {
+	child, adult := sectionBlocks(doc, MsgChildhood), sectionBlocks(doc, MsgAdult)
+	img := image.NewRGBA(image.Rect(0, 0, pngWidth, pngHeight))
+	pngGradient(img)
+
+	faces := make(map[int]font.Face)
+	face := func(size int, bold bool) (font.Face, error) {
+		key := size
+		if bold {
+			key = -size
+		}
+		if fc, ok := faces[key]; ok {
+			return fc, nil
+		}
+		src := f.regular
+		if bold {
+			src = f.bold
+		}
+		fc, err := opentype.NewFace(src, &opentype.FaceOptions{Size: float64(size), DPI: 72, Hinting: font.HintingFull})
+		if err != nil {
+			return nil, appErrors.Wrap(err, appErrors.CodeInternal, "create font face")
+		}
+		faces[key] = fc
+		return fc, nil
+	}
+	defer func() {
+		for _, fc := range faces {
+			_ = fc.Close()
+		}
+	}()
+
+	inner := pngWidth - 2*pngMargin
+	y := pngMargin
+
+	heading, err := face(24, true)
+	if err != nil {
+		return nil, err
+	}
+	y += 24
+	pngDrawString(img, heading, pngMargin, y, pngGold, pngTruncate(heading, fieldValue(child, MsgName).String()+" → "+f.catalog.Heading(MsgAdult), inner))
+
+	title, err := face(64, true)
+	if err != nil {
+		return nil, err
+	}
+	y += 32
+	for _, line := range pngWrap(title, fieldValue(adult, MsgRole).String(), inner, 2) {
+		y += 70
+		pngDrawString(img, title, pngMargin, y, pngText, line)
+	}
+
+	subtitle, err := face(28, false)
+	if err != nil {
+		return nil, err
+	}
+	if field, _ := splitField(fieldValue(adult, MsgField)); field != "" {
+		y += 48
+		pngDrawString(img, subtitle, pngMargin, y, pngGreen, pngTruncate(subtitle, field, inner))
+	}
+
+	qualities := findList(adult, MsgTraits).Items
+	if len(qualities) == 0 {
+		qualities = findList(child, MsgQualities).Items
+	}
+	badge, err := face(pngBadgeSize, false)
+	if err != nil {
+		return nil, err
+	}
+	footer, err := face(20, false)
+	if err != nil {
+		return nil, err
+	}
+
+	bottom := pngHeight - pngMargin - 36
+	badgeHeight := pngBadgeSize + 22
+	bx, by := pngMargin, y+40
+	for _, q := range qualities {
+		label := pngTruncate(badge, q.Name.String(), inner-2*pngBadgePad)
+		w := font.MeasureString(badge, label).Ceil() + 2*pngBadgePad
+		if bx > pngMargin && bx+w > pngMargin+inner {
+			bx = pngMargin
+			by += badgeHeight + pngBadgeGap
+		}
+		if by+badgeHeight > bottom {
+			break
+		}
+		c := pngBadge
+		if isHighlighted(q.Name) {
+			c = pngPersistence
+		}
+		pngBadgeRect(img, image.Rect(bx, by, bx+w, by+badgeHeight), c)
+		pngDrawString(img, badge, bx+pngBadgePad, by+badgeHeight/2+pngBadgeSize*7/20, c, label)
+		bx += w + pngBadgeGap
+	}
+
+	if t := doc.Title.String(); t != "" {
+		pngDrawString(img, footer, pngMargin, pngHeight-pngMargin+4, pngMuted, pngTruncate(footer, t, inner))
+	}
+	return img, nil
+}
+
+func pngGradient(img *image.RGBA) {
+	b := img.Bounds()
+	span := b.Dx() + b.Dy()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			t := (x + y) * 255 / span
+			img.SetRGBA(x, y, color.RGBA{
+				R: pngMix(pngGradientFrom.R, pngGradientTo.R, t),
+				G: pngMix(pngGradientFrom.G, pngGradientTo.G, t),
+				B: pngMix(pngGradientFrom.B, pngGradientTo.B, t),
+				A: 0xff,
+			})
+		}
+	}
+}
+
+func pngMix(from, to uint8, t int) uint8 {
+	return uint8((int(from)*(255-t) + int(to)*t) / 255)
+}
+
+func pngBadgeRect(img *image.RGBA, r image.Rectangle, c color.RGBA) {
+	radius := r.Dy() / 2
+	fill := color.RGBA{R: c.R / 6, G: c.G / 6, B: c.B / 6, A: 0x2a}
+	draw.DrawMask(img, r, image.NewUniform(fill), image.Point{}, roundedRect{r, radius, 0}, r.Min, draw.Over)
+	draw.DrawMask(img, r, image.NewUniform(c), image.Point{}, roundedRect{r, radius, 2}, r.Min, draw.Over)
+}
+
+// roundedRect при stroke > 0 непрозрачен только по контуру.
+type roundedRect struct {
+	r      image.Rectangle
+	radius int
+	stroke int
+}
+
+func (m roundedRect) ColorModel() color.Model { return color.AlphaModel }
+func (m roundedRect) Bounds() image.Rectangle { return m.r }
+
+func (m roundedRect) At(x, y int) color.Color {
+	if !m.inside(x, y, 0) {
+		return color.Alpha{}
+	}
+	if m.stroke > 0 && m.inside(x, y, m.stroke) {
+		return color.Alpha{}
+	}
+	return color.Alpha{A: 0xff}
+}
+
+func (m roundedRect) inside(x, y, inset int) bool {
+	r := m.r.Inset(inset)
+	if !(image.Point{x, y}.In(r)) {
+		return false
+	}
+	radius := max(m.radius-inset, 0)
+	cx := min(max(x, r.Min.X+radius), r.Max.X-1-radius)
+	cy := min(max(y, r.Min.Y+radius), r.Max.Y-1-radius)
+	dx, dy := x-cx, y-cy
+	return dx*dx+dy*dy <= radius*radius
+}
+
+func pngDrawString(img *image.RGBA, face font.Face, x, y int, c color.Color, s string) {
+	d := font.Drawer{Dst: img, Src: image.NewUniform(c), Face: face, Dot: fixed.P(x, y)}
+	d.DrawString(s)
+}
+
+func pngWrap(face font.Face, s string, width, maxLines int) []string {
+	var lines []string
+	current := ""
+	for _, word := range strings.Fields(s) {
+		candidate := word
+		if current != "" {
+			candidate = current + " " + word
+		}
+		if font.MeasureString(face, candidate).Ceil() <= width || current == "" {
+			current = candidate
+			continue
+		}
+		lines = append(lines, current)
+		current = word
+	}
+	if current != "" {
+		lines = append(lines, current)
+	}
+
+	if len(lines) > maxLines {
+		lines = append(lines[:maxLines-1], strings.Join(lines[maxLines-1:], " "))
+	}
+	for i, line := range lines {
+		lines[i] = pngTruncate(face, line, width)
+	}
+	return lines
+}
+
+func pngTruncate(face font.Face, s string, width int) string {
+	if font.MeasureString(face, s).Ceil() <= width {
+		return s
+	}
+	runes := []rune(strings.TrimSuffix(s, "…"))
+	for len(runes) > 0 && font.MeasureString(face, string(runes)+"…").Ceil() > width {
+		runes = runes[:len(runes)-1]
+	}
+	return strings.TrimRight(string(runes), " ") + "…"
+}
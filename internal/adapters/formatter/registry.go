@@ -0,0 +1,443 @@
+package formatter
+
+import (
+	"fmt"
+	"mime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/xeniasokk/field-switcher/internal/ports"
+	appErrors "github.com/xeniasokk/field-switcher/pkg/errors"
+)
+
+const (
+	FormatText       = "text"
+	FormatAccessible = "accessible"
+	FormatTemplate   = "template"
+	FormatJSON       = "json"
+	FormatYAML       = "yaml"
+	FormatMarkdown   = "markdown"
+	FormatHTML       = "html"
+	FormatSVG        = "svg"
+	FormatCSV        = "csv"
+	FormatTSV        = "tsv"
+	FormatDOT        = "dot"
+	FormatMermaid    = "mermaid"
+	FormatPDF        = "pdf"
+	FormatPNG        = "png"
+)
+
+type Capabilities struct {
+	Color bool
+	Width bool
+	// Binary — вывод нельзя печатать в терминал как есть
+	Binary bool
+	// Batch — форматтер реализует ports.BatchFormatterPort
+	Batch bool
+}
+
+type Settings struct {
+	Theme          Theme
+	Catalog        Catalog
+	Color          bool
+	Width          int
+	Boxes          bool
+	Layout         TextLayout
+	TemplateFile   string
+	StrictTemplate bool
+	// CSVColumns — колонки csv и tsv; пусто — DefaultCSVColumns
+	CSVColumns []CSVColumn
+	// CSVListSeparator склеивает многозначные поля csv и tsv; пусто — "; "
+	CSVListSeparator string
+	// FrontMatter добавляет YAML-шапку в markdown
+	FrontMatter bool
+	// IssuedAt — дата выдачи в pdf; нулевая — без даты
+	IssuedAt time.Time
+}
+
+func (s Settings) csvOptions() []CSVOption {
+	opts := []CSVOption{WithCSVCatalog(s.Catalog)}
+	if len(s.CSVColumns) > 0 {
+		opts = append(opts, WithColumns(s.CSVColumns...))
+	}
+	if s.CSVListSeparator != "" {
+		opts = append(opts, WithListSeparator(s.CSVListSeparator))
+	}
+	return opts
+}
+
+type Factory func(s Settings) (ports.FormatterPort, error)
+
+type Registration struct {
+	Name         string
+	MediaTypes   []string
+	Capabilities Capabilities
+	Factory      Factory
+}
+
+type Registry struct {
+	mu      sync.RWMutex
+	byName  map[string]Registration
+	byMedia map[string]string
+	// порядок регистрации решает при равных q в Accept
+	order []string
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		byName:  make(map[string]Registration),
+		byMedia: make(map[string]string),
+	}
+}
+
+func (r *Registry) Register(reg Registration) error {
+	if reg.Name == "" {
+		return appErrors.NewValidationError("formatter name cannot be empty")
+	}
+	if reg.Factory == nil {
+		return appErrors.NewValidationError(fmt.Sprintf("formatter %q has no factory", reg.Name))
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.byName[reg.Name]; ok {
+		return appErrors.New(appErrors.CodeConflict, fmt.Sprintf("formatter %q is already registered", reg.Name))
+	}
+	for _, mt := range reg.MediaTypes {
+		if owner, ok := r.byMedia[strings.ToLower(mt)]; ok {
+			return appErrors.New(appErrors.CodeConflict,
+				fmt.Sprintf("media type %s is already served by formatter %q", mt, owner))
+		}
+	}
+
+	r.byName[reg.Name] = reg
+	for _, mt := range reg.MediaTypes {
+		r.byMedia[strings.ToLower(mt)] = reg.Name
+	}
+	r.order = append(r.order, reg.Name)
+	return nil
+}
+
+func (r *Registry) Lookup(name string) (Registration, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	reg, ok := r.byName[name]
+	if !ok {
+		return Registration{}, appErrors.New(appErrors.CodeNotFound,
+			fmt.Sprintf("unknown format %q, available: %s", name, strings.Join(r.namesLocked(), ", ")))
+	}
+	return reg, nil
+}
+
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.namesLocked()
+}
+
+func (r *Registry) namesLocked() []string {
+	names := append([]string(nil), r.order...)
+	sort.Strings(names)
+	return names
+}
+
+func (r *Registry) New(name string, s Settings) (ports.FormatterPort, error) {
+	reg, err := r.Lookup(name)
+	if err != nil {
+		return nil, err
+	}
+	f, err := reg.Factory(s)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.CodeOf(err), fmt.Sprintf("create %s formatter", name))
+	}
+	return f, nil
+}
+
+type acceptRange struct {
+	mediaType string
+	q         float64
+	index     int
+}
+
+// Negotiate (RFC 9110, §12.5.1) берёт вес форматтера из самого специфичного подходящего
+// диапазона: "text/*;q=0" не отменяет явный "text/plain". При равных q решают точность
+// совпадения, порядок в заголовке, затем порядок регистрации.
+func (r *Registry) Negotiate(accept string) (Registration, error) {
+	var ranges []acceptRange
+	for i, part := range strings.Split(accept, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		mt, params, err := mime.ParseMediaType(part)
+		if err != nil {
+			return Registration{}, appErrors.Wrap(err, appErrors.CodeValidation, fmt.Sprintf("parse accept range %q", part))
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			q, err = strconv.ParseFloat(v, 64)
+			if err != nil || q < 0 || q > 1 {
+				return Registration{}, appErrors.NewValidationError(fmt.Sprintf("invalid quality value in %q", part))
+			}
+		}
+		ranges = append(ranges, acceptRange{mediaType: mt, q: q, index: i})
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var best *acceptRange
+	bestName := ""
+	for _, name := range r.order {
+		ar, ok := bestRange(ranges, r.byName[name].MediaTypes)
+		if !ok || ar.q == 0 {
+			continue
+		}
+		if best == nil || preferRange(ar, *best) {
+			best, bestName = &ar, name
+		}
+	}
+	if best == nil {
+		return Registration{}, appErrors.New(appErrors.CodeNotFound, fmt.Sprintf("no formatter acceptable for %q", accept))
+	}
+	return r.byName[bestName], nil
+}
+
+func bestRange(ranges []acceptRange, mediaTypes []string) (acceptRange, bool) {
+	var best acceptRange
+	found := false
+	for _, mt := range mediaTypes {
+		var match acceptRange
+		matched := false
+		for _, ar := range ranges {
+			if !mediaMatches(ar.mediaType, mt) {
+				continue
+			}
+			if !matched || specificity(ar.mediaType) > specificity(match.mediaType) {
+				match, matched = ar, true
+			}
+		}
+		if !matched {
+			continue
+		}
+		if !found || match.q > best.q ||
+			match.q == best.q && specificity(match.mediaType) > specificity(best.mediaType) {
+			best, found = match, true
+		}
+	}
+	return best, found
+}
+
+func preferRange(a, b acceptRange) bool {
+	if a.q != b.q {
+		return a.q > b.q
+	}
+	if sa, sb := specificity(a.mediaType), specificity(b.mediaType); sa != sb {
+		return sa > sb
+	}
+	return a.index < b.index
+}
+
+func mediaMatches(pattern, mediaType string) bool {
+	if pattern == "*/*" {
+		return true
+	}
+	if prefix, ok := strings.CutSuffix(pattern, "/*"); ok {
+		return strings.HasPrefix(strings.ToLower(mediaType), prefix+"/")
+	}
+	return strings.EqualFold(pattern, mediaType)
+}
+
+func specificity(mediaType string) int {
+	switch {
+	case mediaType == "*/*":
+		return 0
+	case strings.HasSuffix(mediaType, "/*"):
+		return 1
+	default:
+		return 2
+	}
+}
+
+// DefaultRegistry регистрирует text первым, поэтому Accept: */* отдаёт текст.
+func DefaultRegistry() *Registry {
+	r := NewRegistry()
+	for _, reg := range builtinRegistrations() {
+		if err := r.Register(reg); err != nil {
+			panic(err)
+		}
+	}
+	return r
+}
+
+func builtinRegistrations() []Registration {
+	return []Registration{
+		{
+			Name:         FormatText,
+			MediaTypes:   []string{"text/plain"},
+			Capabilities: Capabilities{Color: true, Width: true},
+			Factory: func(s Settings) (ports.FormatterPort, error) {
+				opts := []TextOption{WithColor(s.Color), WithWidth(s.Width), WithBoxes(s.Boxes), WithCatalog(s.Catalog)}
+				if s.Theme.Slots != nil {
+					opts = append(opts, WithTheme(s.Theme))
+				}
+				if s.Layout != "" {
+					opts = append(opts, WithLayout(s.Layout))
+				}
+				return NewTextFormatter(opts...), nil
+			},
+		},
+		{
+			Name:       FormatAccessible,
+			MediaTypes: []string{"text/x-accessible"},
+			Factory: func(s Settings) (ports.FormatterPort, error) {
+				return NewAccessibleFormatter(WithAccessibleCatalog(s.Catalog)), nil
+			},
+		},
+		{
+			Name:         FormatTemplate,
+			Capabilities: Capabilities{Color: true},
+			Factory: func(s Settings) (ports.FormatterPort, error) {
+				if s.TemplateFile == "" {
+					return nil, appErrors.NewValidationError("template format needs a template file")
+				}
+				opts := []TemplateOption{WithTemplateColor(s.Color), WithTemplateCatalog(s.Catalog)}
+				if s.Theme.Slots != nil {
+					opts = append(opts, WithTemplateTheme(s.Theme))
+				}
+				if s.StrictTemplate {
+					opts = append(opts, WithStrict())
+				}
+				f, err := LoadTemplateFile(s.TemplateFile, opts...)
+				if err != nil {
+					return nil, err
+				}
+				return f, nil
+			},
+		},
+		{
+			Name:       FormatJSON,
+			MediaTypes: []string{"application/json"},
+			Factory: func(s Settings) (ports.FormatterPort, error) {
+				return NewJSONFormatter(WithPrettyPrint("  "), WithJSONCatalog(s.Catalog)), nil
+			},
+		},
+		{
+			Name:       FormatYAML,
+			MediaTypes: []string{"application/yaml", "application/x-yaml", "text/yaml"},
+			Factory: func(s Settings) (ports.FormatterPort, error) {
+				return NewYAMLFormatter(WithYAMLCatalog(s.Catalog)), nil
+			},
+		},
+		{
+			Name:       FormatMarkdown,
+			MediaTypes: []string{"text/markdown"},
+			Factory: func(s Settings) (ports.FormatterPort, error) {
+				opts := []MarkdownOption{WithMarkdownCatalog(s.Catalog)}
+				if s.FrontMatter {
+					opts = append(opts, WithFrontMatter())
+				}
+				return NewMarkdownFormatter(opts...), nil
+			},
+		},
+		{
+			Name:       FormatHTML,
+			MediaTypes: []string{"text/html"},
+			Factory: func(s Settings) (ports.FormatterPort, error) {
+				theme := HTMLThemeDark
+				if s.Theme.Name == ThemeLight {
+					theme = HTMLThemeLight
+				}
+				f, err := NewHTMLFormatter(WithHTMLTheme(theme), WithHTMLCatalog(s.Catalog))
+				if err != nil {
+					return nil, err
+				}
+				return f, nil
+			},
+		},
+		{
+			Name:       FormatSVG,
+			MediaTypes: []string{"image/svg+xml"},
+			Factory: func(s Settings) (ports.FormatterPort, error) {
+				return NewSVGFormatter(WithSVGCatalog(s.Catalog)), nil
+			},
+		},
+		{
+			Name:         FormatCSV,
+			MediaTypes:   []string{"text/csv"},
+			Capabilities: Capabilities{Batch: true},
+			Factory: func(s Settings) (ports.FormatterPort, error) {
+				f, err := NewCSVFormatter(s.csvOptions()...)
+				if err != nil {
+					return nil, err
+				}
+				return f, nil
+			},
+		},
+		{
+			Name:         FormatTSV,
+			MediaTypes:   []string{"text/tab-separated-values"},
+			Capabilities: Capabilities{Batch: true},
+			Factory: func(s Settings) (ports.FormatterPort, error) {
+				f, err := NewTSVFormatter(s.csvOptions()...)
+				if err != nil {
+					return nil, err
+				}
+				return f, nil
+			},
+		},
+		{
+			Name:         FormatDOT,
+			MediaTypes:   []string{"text/vnd.graphviz"},
+			Capabilities: Capabilities{Batch: true},
+			Factory: func(s Settings) (ports.FormatterPort, error) {
+				f, err := NewGraphFormatter(GraphDOT, WithGraphCatalog(s.Catalog))
+				if err != nil {
+					return nil, err
+				}
+				return f, nil
+			},
+		},
+		{
+			Name:         FormatMermaid,
+			MediaTypes:   []string{"text/vnd.mermaid"},
+			Capabilities: Capabilities{Batch: true},
+			Factory: func(s Settings) (ports.FormatterPort, error) {
+				f, err := NewGraphFormatter(GraphMermaid, WithGraphCatalog(s.Catalog))
+				if err != nil {
+					return nil, err
+				}
+				return f, nil
+			},
+		},
+		{
+			Name:         FormatPDF,
+			MediaTypes:   []string{"application/pdf"},
+			Capabilities: Capabilities{Binary: true},
+			Factory: func(s Settings) (ports.FormatterPort, error) {
+				opts := []PDFOption{WithPDFCatalog(s.Catalog)}
+				if !s.IssuedAt.IsZero() {
+					opts = append(opts, WithIssuedAt(s.IssuedAt))
+				}
+				return NewPDFFormatter(opts...), nil
+			},
+		},
+		{
+			Name:         FormatPNG,
+			MediaTypes:   []string{"image/png"},
+			Capabilities: Capabilities{Binary: true},
+			Factory: func(s Settings) (ports.FormatterPort, error) {
+				f, err := NewPNGFormatter(WithPNGCatalog(s.Catalog))
+				if err != nil {
+					return nil, err
+				}
+				return f, nil
+			},
+		},
+	}
+}
@@ -0,0 +1,210 @@
+package formatter
+
+import (
+	"strings"
+
+	"github.com/fatih/color"
+
+	"github.com/xeniasokk/field-switcher/pkg/document"
+)
+
+type TextLayout string
+
+const (
+	LayoutStacked    TextLayout = "stacked"
+	LayoutSideBySide TextLayout = "side-by-side"
+)
+
+const (
+	columnGapWidth = 3 // " → "
+	// уже этого колонки нечитаемы, и форматтер возвращается к обычной раскладке
+	minColumnWidth = 24
+)
+
+type columnCell struct {
+	spans []span
+	// не nil — ячейка пункт списка с маркером этого цвета
+	bullet *color.Color
+}
+
+type columnRow struct {
+	left, right columnCell
+	arrow       bool
+}
+
+// WriteSideBySide ставит качество напротив черты, в которую оно превратилось; false — терминал
+// слишком узкий.
+func (f *TextFormatter) WriteSideBySide(b *strings.Builder, doc document.Document, colors colorScheme) bool {
+	rows := sideBySideRows(sectionBlocks(doc, MsgChildhood), sectionBlocks(doc, MsgAdult), colors, f.catalog)
+
+	leftWidth, rightWidth := 0, 0
+	if f.width > 0 {
+		leftWidth = (f.width - columnGapWidth) / 2
+		rightWidth = f.width - columnGapWidth - leftWidth
+		if leftWidth < minColumnWidth {
+			return false
+		}
+	} else {
+		for _, row := range rows {
+			leftWidth = max(leftWidth, cellWidth(row.left))
+		}
+	}
+
+	for _, row := range rows {
+		left := renderCell(row.left, leftWidth)
+		right := renderCell(row.right, rightWidth)
+		for i := 0; i < max(len(left), len(right)); i++ {
+			line := ""
+			if i < len(left) {
+				line = left[i]
+			}
+			gap := "   "
+			if row.arrow && i == 0 {
+				gap = " " + colors.secondary.Sprint("→") + " "
+			}
+			if i < len(right) && right[i] != "" {
+				line = padRight(line, leftWidth) + gap + right[i]
+			}
+			b.WriteString(line)
+			b.WriteString("\n")
+		}
+	}
+	return true
+}
+
+func sideBySideRows(childhood, adult []document.Block, colors colorScheme, catalog Catalog) []columnRow {
+	text := func(blocks []document.Block, label MessageKey) string {
+		return fieldValue(blocks, label).String()
+	}
+
+	cell := func(spans ...span) columnCell {
+		return columnCell{spans: spans}
+	}
+	childHeading, adultHeading := catalog.Heading(MsgChildhood), catalog.Heading(MsgAdult)
+	underline := func(s string) string {
+		return strings.Repeat("-", DisplayWidth(s))
+	}
+
+	rows := []columnRow{
+		{
+			left:  cell(span{childHeading, colors.childhoodSection}),
+			right: cell(span{adultHeading, colors.adultSection}),
+		},
+		{
+			left:  cell(span{underline(childHeading), colors.childhoodSection}),
+			right: cell(span{underline(adultHeading), colors.adultSection}),
+		},
+		{
+			left:  cell(span{text(childhood, MsgName), colors.value}),
+			right: cell(span{text(adult, MsgRole), colors.value}),
+			arrow: true,
+		},
+	}
+	if desired, desc := text(childhood, MsgRole), text(adult, MsgDescription); desc != "" || desired != "" {
+		rows = append(rows, columnRow{
+			left:  cell(span{desired, colors.secondary}),
+			right: cell(span{desc, colors.secondary}),
+			arrow: desired != "" && desc != "",
+		})
+	}
+	rows = append(rows,
+		columnRow{
+			left:  cell(fieldSpans(fieldValue(childhood, MsgField), colors)...),
+			right: cell(fieldSpans(fieldValue(adult, MsgField), colors)...),
+			arrow: true,
+		},
+		columnRow{},
+	)
+
+	qualities := findList(childhood, MsgQualities).Items
+	traits := findList(adult, MsgTraits).Items
+	used := make([]bool, len(traits))
+	if len(qualities) > 0 || len(traits) > 0 {
+		rows = append(rows, columnRow{
+			left:  cell(span{catalog.Label(MsgQualities), colors.label}),
+			right: cell(span{catalog.Label(MsgTraits), colors.label}),
+		})
+	}
+	for _, q := range qualities {
+		row := columnRow{left: columnCell{spans: qualitySpans(q, colors), bullet: colors.bullet}}
+		for i, t := range traits {
+			if !used[i] && t.Name.String() == q.Name.String() {
+				used[i] = true
+				row.right = columnCell{spans: qualitySpans(t, colors), bullet: colors.bullet}
+				row.arrow = true
+				break
+			}
+		}
+		if !row.arrow {
+			row.right = cell(span{catalog.T(MsgNotPreserved), colors.secondary})
+		}
+		rows = append(rows, row)
+	}
+	for i, t := range traits {
+		if !used[i] {
+			rows = append(rows, columnRow{right: columnCell{spans: qualitySpans(t, colors), bullet: colors.bullet}})
+		}
+	}
+
+	if stack := findList(adult, MsgStack).Items; len(stack) > 0 {
+		rows = append(rows, columnRow{}, columnRow{right: cell(span{catalog.Label(MsgStack), colors.label})})
+		for _, s := range stack {
+			stackColor := colors.stack
+			if isHighlighted(s.Name) {
+				stackColor = colors.persistence
+			}
+			rows = append(rows, columnRow{
+				right: columnCell{spans: []span{{s.Name.String(), stackColor}}, bullet: colors.bullet},
+			})
+		}
+	}
+	return rows
+}
+
+func qualitySpans(q document.Item, colors colorScheme) []span {
+	nameColor := colors.quality
+	if isHighlighted(q.Name) {
+		nameColor = colors.persistence
+	}
+	spans := []span{{q.Name.String(), nameColor}}
+	if sources := q.Refs; len(sources) > 0 {
+		spans = append(spans, span{"[" + strings.Join(sources, ", ") + "]", colors.secondary})
+	}
+	return spans
+}
+
+func renderCell(c columnCell, width int) []string {
+	if c.bullet == nil {
+		return wrapSpans(c.spans, width)
+	}
+	inner := 0
+	if width > 0 {
+		inner = max(width-2, 1)
+	}
+	lines := wrapSpans(c.spans, inner)
+	for i := range lines {
+		if i == 0 {
+			lines[i] = c.bullet.Sprint("•") + " " + lines[i]
+		} else {
+			lines[i] = "  " + lines[i]
+		}
+	}
+	return lines
+}
+
+func cellWidth(c columnCell) int {
+	w := 0
+	for _, line := range renderCell(c, 0) {
+		w = max(w, DisplayWidth(line))
+	}
+	return w
+}
+
+func fieldSpans(field document.Text, colors colorScheme) []span {
+	name, env := splitField(field)
+	spans := []span{{name, colors.value}}
+	if env != "" {
+		spans = append(spans, span{"(" + env + ")", colors.secondary})
+	}
+	return spans
+}
@@ -0,0 +1,60 @@
+package formatter
+
+import (
+	"context"
+	"io"
+
+	"github.com/xeniasokk/field-switcher/internal/ports"
+	appErrors "github.com/xeniasokk/field-switcher/pkg/errors"
+)
+
+// Stream возвращает потоковые форматтеры как есть, остальные пишут готовую строку одним вызовом.
+func Stream(f ports.FormatterPort) ports.StreamFormatterPort {
+	if sf, ok := f.(ports.StreamFormatterPort); ok {
+		return sf
+	}
+	return stringStream{f}
+}
+
+type stringStream struct {
+	ports.FormatterPort
+}
+
+func (s stringStream) FormatTo(ctx context.Context, w io.Writer, vm ports.ViewModel) error {
+	text, err := s.Format(ctx, vm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(w, text); err != nil {
+		return appErrors.Wrap(err, appErrors.CodeIO, "write formatted output")
+	}
+	return nil
+}
+
+// streamWriter запоминает первую ошибку записи, чтобы отличить её от ошибки форматтера.
+type streamWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (s *streamWriter) Write(p []byte) (int, error) {
+	if s.err != nil {
+		return 0, s.err
+	}
+	n, err := s.w.Write(p)
+	if err != nil {
+		s.err = err
+	}
+	return n, err
+}
+
+func (s *streamWriter) WriteString(str string) (int, error) {
+	return s.Write([]byte(str))
+}
+
+func (s *streamWriter) ioError() error {
+	if s.err == nil {
+		return nil
+	}
+	return appErrors.Wrap(s.err, appErrors.CodeIO, "write formatted output")
+}
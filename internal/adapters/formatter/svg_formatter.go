@@ -0,0 +1,293 @@
+package formatter
+
+import (
+	"cmp"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/xeniasokk/field-switcher/internal/ports"
+	"github.com/xeniasokk/field-switcher/pkg/document"
+)
+
+var _ ports.FormatterPort = (*SVGFormatter)(nil)
+
+const (
+	svgWidth      = 1000
+	svgHeight     = 520
+	svgMargin     = 30
+	svgCardWidth  = 420
+	svgCardHeight = 460
+	svgPadding    = 24
+	svgTopQuality = 4
+)
+
+// SVGFormatter оценивает ширину текста по таблице средних ширин глифов, а не по шрифту,
+// поэтому вывод детерминирован.
+type SVGFormatter struct {
+	topQualities int
+	catalog      Catalog
+}
+
+type SVGOption func(*SVGFormatter)
+
+func WithTopQualities(n int) SVGOption {
+	return func(f *SVGFormatter) {
+		f.topQualities = n
+	}
+}
+
+func WithSVGCatalog(catalog Catalog) SVGOption {
+	return func(f *SVGFormatter) {
+		f.catalog = catalog
+	}
+}
+
+func NewSVGFormatter(opts ...SVGOption) *SVGFormatter {
+	f := &SVGFormatter{topQualities: svgTopQuality}
+	for _, opt := range opts {
+		opt(f)
+	}
+	if f.topQualities < 1 {
+		f.topQualities = svgTopQuality
+	}
+	return f
+}
+
+func (f *SVGFormatter) Format(ctx context.Context, vm ports.ViewModel) (string, error) {
+	_ = ctx
+
+	var b strings.Builder
+	_, _ = fmt.Fprintf(&b,
+		`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="DejaVu Sans, Verdana, sans-serif">`+"\n",
+		svgWidth, svgHeight, svgWidth, svgHeight,
+	)
+	b.WriteString(`<rect width="100%" height="100%" fill="#14161a"/>` + "\n")
+
+	left := svgMargin
+	right := svgWidth - svgMargin - svgCardWidth
+	doc := f.catalog.Localize(vm.Document())
+	f.writePlayerCard(&b, left, sectionBlocks(doc, MsgChildhood))
+	f.writeArrow(&b, left+svgCardWidth, right)
+	f.writeDeveloperCard(&b, right, sectionBlocks(doc, MsgAdult))
+
+	if title := doc.Title.String(); title != "" {
+		svgText(&b, svgWidth/2, svgHeight-8, 13, "#8b9098", `text-anchor="middle"`, svgTruncate(title, 13, svgWidth-2*svgMargin))
+	}
+
+	b.WriteString("</svg>\n")
+	return b.String(), nil
+}
+
+func (f *SVGFormatter) writePlayerCard(b *strings.Builder, x int, child []document.Block) {
+	svgCard(b, x, "#e5c07b")
+	inner := svgCardWidth - 2*svgPadding
+	tx := x + svgPadding
+	y := svgMargin + svgPadding + 14
+
+	svgText(b, tx, y, 14, "#e5c07b", `font-weight="bold" letter-spacing="2"`, f.catalog.Heading(MsgChildhood))
+	y += 44
+	for _, line := range svgWrap(fieldValue(child, MsgName).String(), 30, inner, 2) {
+		svgText(b, tx, y, 30, "#e6e6e6", `font-weight="bold"`, line)
+		y += 36
+	}
+	for _, line := range svgWrap(fieldValue(child, MsgRole).String(), 16, inner, 2) {
+		svgText(b, tx, y, 16, "#8b9098", "", line)
+		y += 22
+	}
+
+	y += 18
+	// на карточку попадают самые сильные качества; при равной силе — первые по порядку
+	qualities := slices.Clone(findList(child, MsgQualities).Items)
+	slices.SortStableFunc(qualities, func(a, b document.Item) int {
+		return cmp.Compare(b.Weight, a.Weight)
+	})
+	if len(qualities) > f.topQualities {
+		qualities = qualities[:f.topQualities]
+	}
+	bottom := svgMargin + svgCardHeight - svgPadding
+	for _, q := range qualities {
+		nameLines := svgWrap(q.Name.String(), 17, inner-18, 2)
+		descLines := svgWrap(q.Detail.String(), 13, inner-18, 1)
+		if y+22*(len(nameLines)-1)+18*len(descLines) > bottom {
+			break
+		}
+
+		fill := "#4fd1e8"
+		if isHighlighted(q.Name) {
+			fill = "#ff6b6b"
+		}
+		_, _ = fmt.Fprintf(b, `<circle cx="%d" cy="%d" r="4" fill="%s"/>`+"\n", tx+4, y-5, fill)
+		for _, line := range nameLines {
+			svgText(b, tx+18, y, 17, fill, "", line)
+			y += 22
+		}
+		for _, line := range descLines {
+			svgText(b, tx+18, y, 13, "#8b9098", "", line)
+			y += 18
+		}
+		y += 10
+	}
+}
+
+func (f *SVGFormatter) writeDeveloperCard(b *strings.Builder, x int, adult []document.Block) {
+	svgCard(b, x, "#98c379")
+	inner := svgCardWidth - 2*svgPadding
+	tx := x + svgPadding
+	y := svgMargin + svgPadding + 14
+
+	svgText(b, tx, y, 14, "#98c379", `font-weight="bold" letter-spacing="2"`, f.catalog.Heading(MsgAdult))
+	y += 44
+	for _, line := range svgWrap(fieldValue(adult, MsgRole).String(), 30, inner, 2) {
+		svgText(b, tx, y, 30, "#e6e6e6", `font-weight="bold"`, line)
+		y += 36
+	}
+	field, _ := splitField(fieldValue(adult, MsgField))
+	for _, line := range svgWrap(field, 16, inner, 1) {
+		svgText(b, tx, y, 16, "#8b9098", "", line)
+		y += 22
+	}
+
+	y += 18
+	bx := tx
+	const badgeHeight, badgeFont, badgeGap, badgePad = 28, 14, 8, 12
+	for _, s := range itemNames(findList(adult, MsgStack)) {
+		label := svgTruncate(s, badgeFont, inner-2*badgePad)
+		w := int(svgTextWidth(label, badgeFont)) + 2*badgePad
+		if bx > tx && bx+w > tx+inner {
+			bx = tx
+			y += badgeHeight + badgeGap
+		}
+		if y+badgeHeight > svgMargin+svgCardHeight-svgPadding {
+			break
+		}
+		_, _ = fmt.Fprintf(b,
+			`<rect x="%d" y="%d" width="%d" height="%d" rx="14" fill="none" stroke="#d19ad8"/>`+"\n",
+			bx, y, w, badgeHeight,
+		)
+		svgText(b, bx+badgePad, y+19, badgeFont, "#d19ad8", "", label)
+		bx += w + badgeGap
+	}
+}
+
+func (f *SVGFormatter) writeArrow(b *strings.Builder, from, to int) {
+	cy := svgMargin + svgCardHeight/2
+	_, _ = fmt.Fprintf(b,
+		`<path d="M %d %d H %d M %d %d L %d %d L %d %d" stroke="#e6e6e6" stroke-width="3" fill="none"/>`+"\n",
+		from+20, cy, to-20, to-32, cy-10, to-20, cy, to-32, cy+10,
+	)
+}
+
+func svgCard(b *strings.Builder, x int, stroke string) {
+	_, _ = fmt.Fprintf(b,
+		`<rect x="%d" y="%d" width="%d" height="%d" rx="18" fill="#1e2127" stroke="%s" stroke-width="2"/>`+"\n",
+		x, svgMargin, svgCardWidth, svgCardHeight, stroke,
+	)
+}
+
+func svgText(b *strings.Builder, x, y, size int, fill, attrs, text string) {
+	if attrs != "" {
+		attrs = " " + attrs
+	}
+	_, _ = fmt.Fprintf(b, `<text x="%d" y="%d" font-size="%d" fill="%s"%s>`, x, y, size, fill, attrs)
+	_ = xml.EscapeText(b, []byte(text))
+	b.WriteString("</text>\n")
+}
+
+// svgGlyphWidth — примерная ширина глифа в долях кегля для sans-serif шрифта.
+func svgGlyphWidth(r rune) float64 {
+	switch {
+	case r == ' ':
+		return 0.32
+	case unicode.Is(unicode.Mn, r):
+		return 0
+	case isWide(r):
+		return 1.0
+	case unicode.IsUpper(r):
+		return 0.70
+	case unicode.IsDigit(r), unicode.IsLower(r):
+		return 0.60
+	case r == '—':
+		return 0.80
+	default:
+		return 0.40
+	}
+}
+
+func svgTextWidth(s string, size int) float64 {
+	w := 0.0
+	for _, r := range s {
+		w += svgGlyphWidth(r)
+	}
+	return w * float64(size)
+}
+
+// svgWrap режет слово длиннее строки посимвольно, а не влезшее в maxLines заменяет многоточием.
+func svgWrap(s string, size, width, maxLines int) []string {
+	var lines []string
+	current := ""
+	for _, word := range strings.Fields(s) {
+		candidate := word
+		if current != "" {
+			candidate = current + " " + word
+		}
+		if svgTextWidth(candidate, size) <= float64(width) {
+			current = candidate
+			continue
+		}
+		if current != "" {
+			lines = append(lines, current)
+		}
+		current = word
+		for svgTextWidth(current, size) > float64(width) {
+			head, tail := svgSplitAt(current, size, width)
+			lines = append(lines, head)
+			current = tail
+		}
+	}
+	if current != "" {
+		lines = append(lines, current)
+	}
+
+	if len(lines) > maxLines {
+		last := strings.Join(lines[maxLines-1:], " ")
+		lines = append(lines[:maxLines-1], svgTruncate(last+"…", size, width))
+	}
+	return lines
+}
+
+func svgSplitAt(s string, size, width int) (string, string) {
+	runes := []rune(s)
+	w := 0.0
+	for i, r := range runes {
+		w += svgGlyphWidth(r) * float64(size)
+		if w > float64(width) {
+			return string(runes[:max(i, 1)]), string(runes[max(i, 1):])
+		}
+	}
+	return s, ""
+}
+
+func svgTruncate(s string, size, width int) string {
+	if svgTextWidth(s, size) <= float64(width) {
+		return s
+	}
+	runes := []rune(strings.TrimSuffix(s, "…"))
+	for len(runes) > 0 && svgTextWidth(string(runes)+"…", size) > float64(width) {
+		runes = runes[:len(runes)-1]
+	}
+	return strings.TrimRight(string(runes), " ") + "…"
+}
+
+func isWide(r rune) bool {
+	return unicode.Is(unicode.Han, r) ||
+		unicode.Is(unicode.Hangul, r) ||
+		unicode.Is(unicode.Hiragana, r) ||
+		unicode.Is(unicode.Katakana, r) ||
+		(r >= 0x1F300 && r <= 0x1FAFF) ||
+		(r >= 0x2600 && r <= 0x27BF) ||
+		(r >= 0xFF00 && r <= 0xFF60)
+}
@@ -0,0 +1,209 @@
+package formatter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"text/template"
+
+	"github.com/fatih/color"
+
+	"github.com/xeniasokk/field-switcher/internal/ports"
+	"github.com/xeniasokk/field-switcher/pkg/document"
+	appErrors "github.com/xeniasokk/field-switcher/pkg/errors"
+)
+
+var _ ports.FormatterPort = (*TemplateFormatter)(nil)
+
+// TemplateFormatter отдаёт шаблону документ JSONFormatter с ключами docs/schema/view-model.v1.json.
+type TemplateFormatter struct {
+	name    string
+	source  string
+	strict  bool
+	theme   Theme
+	color   bool
+	catalog Catalog
+	tmpl    *template.Template
+}
+
+type TemplateOption func(*TemplateFormatter)
+
+// WithStrict включает missingkey=error: обращение к отсутствующему полю — ошибка, а не "<no value>".
+func WithStrict() TemplateOption {
+	return func(f *TemplateFormatter) {
+		f.strict = true
+	}
+}
+
+func WithTemplateTheme(theme Theme) TemplateOption {
+	return func(f *TemplateFormatter) {
+		f.theme = theme
+	}
+}
+
+func WithTemplateColor(enabled bool) TemplateOption {
+	return func(f *TemplateFormatter) {
+		f.color = enabled
+	}
+}
+
+func WithTemplateCatalog(catalog Catalog) TemplateOption {
+	return func(f *TemplateFormatter) {
+		f.catalog = catalog
+	}
+}
+
+func WithTemplateName(name string) TemplateOption {
+	return func(f *TemplateFormatter) {
+		f.name = name
+	}
+}
+
+func NewTemplateFormatter(source string, opts ...TemplateOption) (*TemplateFormatter, error) {
+	f := &TemplateFormatter{
+		name:   "template",
+		source: source,
+		theme:  builtinThemes[ThemeDark],
+	}
+	for _, opt := range opts {
+		opt(f)
+	}
+
+	tmpl := template.New(f.name).Funcs(f.funcs())
+	if f.strict {
+		tmpl = tmpl.Option("missingkey=error")
+	}
+	tmpl, err := tmpl.Parse(source)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.CodeValidation, "parse output template")
+	}
+	f.tmpl = tmpl
+	return f, nil
+}
+
+func LoadTemplateFile(path string, opts ...TemplateOption) (*TemplateFormatter, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.CodeIO, fmt.Sprintf("read template file %s", path))
+	}
+	return NewTemplateFormatter(string(data), append([]TemplateOption{WithTemplateName(path)}, opts...)...)
+}
+
+func (f *TemplateFormatter) Format(ctx context.Context, vm ports.ViewModel) (string, error) {
+	_ = ctx
+
+	data, err := templateData(f.catalog.Localize(vm.Document()))
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := f.tmpl.Execute(&buf, data); err != nil {
+		return "", appErrors.Wrap(err, appErrors.CodeValidation, "execute output template")
+	}
+	return buf.String(), nil
+}
+
+// templateData переводит документ в map: только для map опция missingkey=error что-то значит.
+func templateData(doc document.Document) (map[string]any, error) {
+	raw, err := json.Marshal(NewJSONDocument(doc))
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.CodeInternal, "encode template data")
+	}
+	var data map[string]any
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, appErrors.Wrap(err, appErrors.CodeInternal, "decode template data")
+	}
+	return data, nil
+}
+
+func (f *TemplateFormatter) funcs() template.FuncMap {
+	return template.FuncMap{
+		"color":  f.colorize,
+		"wrap":   templateWrap,
+		"join":   templateJoin,
+		"upper":  strings.ToUpper,
+		"plural": templatePlural,
+		"t":      func(key string) string { return f.catalog.T(MessageKey(key)) },
+	}
+}
+
+// colorize принимает слот темы (quality, persistence, ...) или имя цвета (red, #ff8800).
+func (f *TemplateFormatter) colorize(name string, value any) (string, error) {
+	text := fmt.Sprint(value)
+	var c *color.Color
+	if _, ok := builtinThemes[ThemeDark].Slots[Slot(name)]; ok {
+		c = f.theme.color(Slot(name))
+	} else {
+		attrs, err := colorAttributes(name, false)
+		if err != nil {
+			return "", err
+		}
+		c = color.New(attrs...)
+	}
+	if !f.color {
+		return text, nil
+	}
+	c.EnableColor()
+	return c.Sprint(text), nil
+}
+
+func templateWrap(width int, value any) string {
+	return strings.Join(wrapSpans([]span{{fmt.Sprint(value), nil}}, width), "\n")
+}
+
+func templateJoin(sep string, list any) (string, error) {
+	if list == nil {
+		return "", nil
+	}
+	v := reflect.ValueOf(list)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return "", fmt.Errorf("join: expected a list, got %T", list)
+	}
+	parts := make([]string, v.Len())
+	for i := range parts {
+		parts[i] = fmt.Sprint(v.Index(i).Interface())
+	}
+	return strings.Join(parts, sep), nil
+}
+
+// templatePlural: две формы — английское правило, три — русское (1 качество, 2 качества, 5 качеств).
+func templatePlural(n any, forms ...string) (string, error) {
+	var count int
+	switch v := n.(type) {
+	case int:
+		count = v
+	case int64:
+		count = int(v)
+	case float64:
+		count = int(v)
+	default:
+		return "", fmt.Errorf("plural: expected a number, got %T", n)
+	}
+	if count < 0 {
+		count = -count
+	}
+
+	switch len(forms) {
+	case 2:
+		if count == 1 {
+			return forms[0], nil
+		}
+		return forms[1], nil
+	case 3:
+		switch {
+		case count%10 == 1 && count%100 != 11:
+			return forms[0], nil
+		case count%10 >= 2 && count%10 <= 4 && (count%100 < 12 || count%100 > 14):
+			return forms[1], nil
+		default:
+			return forms[2], nil
+		}
+	default:
+		return "", fmt.Errorf("plural: expected 2 or 3 forms, got %d", len(forms))
+	}
+}
@@ -0,0 +1,83 @@
+package tests
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/xeniasokk/field-switcher/internal/adapters/formatter"
+	"github.com/xeniasokk/field-switcher/internal/adapters/presenter"
+	"github.com/xeniasokk/field-switcher/internal/domain/dream"
+	"github.com/xeniasokk/field-switcher/internal/ports"
+	"github.com/xeniasokk/field-switcher/pkg/document"
+)
+
+func TestAccessibleFormatterFormat(t *testing.T) {
+	ctx := context.Background()
+	child, err := dream.NewDefaultFootballerDream()
+	if err != nil {
+		t.Fatalf("failed to create default footballer dream: %v", err)
+	}
+	f, _ := dream.NewField("Поле разработки", "Команда разработчиков")
+	a, _ := dream.NewAdult(
+		"Тимлид",
+		"Капитан команды — «ответственность» за команду…",
+		f,
+		[]string{"Go", "CI/CD"},
+		child.Qualities(),
+		"Ты не отказался от мечты — ты просто сменил поле",
+	)
+
+	tests := []struct {
+		name         string
+		vm           ports.ViewModel
+		wantContains []string
+	}{
+		{
+			name: "sections and numbered lists",
+			vm: presenter.NewConsoleViewModel(
+				"field-switcher — трансформация мечты", child, a, "Сохранено качеств: 5",
+			).WithNote(document.Emphasized("Упорство — твой главный союзник", document.EmphasisHighlight)),
+			wantContains: []string{
+				"Заголовок: field-switcher - трансформация мечты.\n",
+				"Раздел 1 из 3: детская мечта.\n",
+				"Качества, 5 пунктов.\n1. " + child.Qualities()[0].Name(),
+				"Стек, 2 пункта.\n1. Go.\n2. CI/CD.\n",
+				`Описание: Капитан команды - "ответственность" за команду...`,
+				"Сохранено качеств: 5.\nУпорство - твой главный союзник.\n",
+				"Конец раздела: итог.\n",
+			},
+		},
+		{
+			name: "merged sources get their own section",
+			vm: func() ports.ViewModel {
+				vm := presenter.NewConsoleViewModel("", child, a, "")
+				return vm.WithSources([]dream.ChildhoodDream{child, child})
+			}(),
+			wantContains: []string{"Раздел 1 из 4: исходные мечты.\n", "Мечты, 2 пункта.\n"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := formatter.NewAccessibleFormatter().Format(ctx, tt.vm)
+			if err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+			for _, want := range tt.wantContains {
+				if !strings.Contains(out, want) {
+					t.Fatalf("expected output to contain %q:\n%s", want, out)
+				}
+			}
+			for _, r := range out {
+				if r > unicode.MaxASCII && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+					t.Fatalf("non-ASCII punctuation or symbol %q in output:\n%s", r, out)
+				}
+				if r == '\x1b' {
+					t.Fatalf("ANSI escape in output:\n%s", out)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package tests
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/xeniasokk/field-switcher/internal/adapters/formatter"
+	"github.com/xeniasokk/field-switcher/internal/adapters/presenter"
+	"github.com/xeniasokk/field-switcher/internal/application/usecase/transform"
+	"github.com/xeniasokk/field-switcher/internal/domain/dream"
+	"github.com/xeniasokk/field-switcher/internal/ports"
+	appErrors "github.com/xeniasokk/field-switcher/pkg/errors"
+)
+
+func TestParseLocale(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    formatter.Locale
+		wantErr bool
+	}{
+		{in: "en", want: formatter.LocaleEN},
+		{in: "en-US", want: formatter.LocaleEN},
+		{in: "ru_RU.UTF-8", want: formatter.LocaleRU},
+		{in: " RU ", want: formatter.LocaleRU},
+		{in: "de", wantErr: true},
+		{in: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := formatter.ParseLocale(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseLocale(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if tt.wantErr && !appErrors.IsCode(err, appErrors.CodeValidation) {
+				t.Fatalf("expected validation error, got %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("ParseLocale(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCatalogPlural(t *testing.T) {
+	ru, _ := formatter.NewCatalog(formatter.LocaleRU)
+	en, _ := formatter.NewCatalog(formatter.LocaleEN)
+
+	tests := []struct {
+		catalog formatter.Catalog
+		n       int
+		want    string
+	}{
+		{ru, 1, "пункт"},
+		{ru, 3, "пункта"},
+		{ru, 12, "пунктов"},
+		{ru, 21, "пункт"},
+		{en, 1, "item"},
+		{en, 2, "items"},
+		{formatter.Catalog{}, 5, "пунктов"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.catalog.Plural(formatter.MsgItems, tt.n); got != tt.want {
+			t.Fatalf("Plural(%s, %d) = %q, want %q", tt.catalog.Locale(), tt.n, got, tt.want)
+		}
+	}
+}
+
+// На английском каталоге и английских данных в выводе не должно остаться ни одной кириллической буквы.
+func TestFormattersEnglishCatalog(t *testing.T) {
+	ctx := context.Background()
+	q1, _ := dream.NewQuality("Team spirit", "Play for the common result")
+	q2, _ := dream.NewQuality("Grit", "Never give up")
+	pitch, _ := dream.NewField("Football pitch", "Stadium")
+	child, err := dream.NewChildhoodDream(dream.TypeFootballer, "Footballer", "Forward", pitch, []dream.Quality{q1, q2})
+	if err != nil {
+		t.Fatalf("failed to create childhood dream: %v", err)
+	}
+	dev, _ := dream.NewField("Development", "Team")
+	a, _ := dream.NewAdult("Team lead", "Captain of a new team", dev, []string{"Go"}, []dream.Quality{q1}, "You changed the field")
+	vm := presenter.NewConsoleViewModel("Dream transformation", child, a, "Kept 1 quality").
+		WithSources([]dream.ChildhoodDream{child, child})
+
+	en, err := formatter.NewCatalog(formatter.LocaleEN)
+	if err != nil {
+		t.Fatalf("NewCatalog() error = %v", err)
+	}
+	html, _ := formatter.NewHTMLFormatter(formatter.WithHTMLCatalog(en))
+	tmpl, _ := formatter.NewTemplateFormatter(`{{ t "childhood" }}: {{ .childhood.display_name }}`, formatter.WithTemplateCatalog(en))
+
+	tests := []struct {
+		name      string
+		formatter ports.FormatterPort
+	}{
+		{"text", formatter.NewTextFormatter(formatter.WithColor(false), formatter.WithCatalog(en))},
+		{"text boxes", formatter.NewTextFormatter(formatter.WithColor(false), formatter.WithCatalog(en), formatter.WithBoxes(true))},
+		{"side by side", formatter.NewTextFormatter(
+			formatter.WithColor(false), formatter.WithCatalog(en), formatter.WithLayout(formatter.LayoutSideBySide),
+		)},
+		{"accessible", formatter.NewAccessibleFormatter(formatter.WithAccessibleCatalog(en))},
+		{"markdown", formatter.NewMarkdownFormatter(formatter.WithMarkdownCatalog(en))},
+		{"html", html},
+		{"svg", formatter.NewSVGFormatter(formatter.WithSVGCatalog(en))},
+		{"template", tmpl},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := tt.formatter.Format(ctx, vm)
+			if err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+			for _, r := range out {
+				if unicode.Is(unicode.Cyrillic, r) {
+					t.Fatalf("found untranslated text %q in output:\n%s", r, out)
+				}
+			}
+		})
+	}
+}
+
+// Заголовок, заметки и строку сводки пишет presenter; на английском каталоге они тоже должны
+// быть английскими. Упорство — имя качества из данных, а не подпись, поэтому его вырезаем.
+func TestPresentersEnglishChrome(t *testing.T) {
+	ctx := context.Background()
+	q1, _ := dream.NewQuality("Team spirit", "Play for the common result")
+	q2, _ := dream.NewQuality(dream.QualityPersistence, "Never give up")
+	pitch, _ := dream.NewField("Football pitch", "Stadium")
+	child, err := dream.NewChildhoodDream(dream.TypeFootballer, "Footballer", "Forward", pitch, []dream.Quality{q1, q2})
+	if err != nil {
+		t.Fatalf("failed to create childhood dream: %v", err)
+	}
+	dev, _ := dream.NewField("Development", "Team")
+	a, _ := dream.NewAdult("Team lead", "Captain of a new team", dev, []string{"Go"}, []dream.Quality{q1, q2}, "")
+	output := transform.NewOutput(child, a)
+
+	en, err := formatter.NewCatalog(formatter.LocaleEN)
+	if err != nil {
+		t.Fatalf("NewCatalog() error = %v", err)
+	}
+	registry := formatter.DefaultRegistry()
+
+	for _, mode := range []presenter.Mode{presenter.ModeCompact, presenter.ModeDetailed, presenter.ModeExecutive} {
+		p, err := presenter.NewPresenter(mode)
+		if err != nil {
+			t.Fatalf("NewPresenter(%s) error = %v", mode, err)
+		}
+		vm, err := p.Present(ctx, output)
+		if err != nil {
+			t.Fatalf("Present() error = %v", err)
+		}
+		for _, name := range []string{
+			formatter.FormatText, formatter.FormatAccessible, formatter.FormatMarkdown, formatter.FormatHTML,
+			formatter.FormatJSON, formatter.FormatYAML, formatter.FormatCSV,
+		} {
+			t.Run(string(mode)+"/"+name, func(t *testing.T) {
+				f, err := registry.New(name, formatter.Settings{Catalog: en})
+				if err != nil {
+					t.Fatalf("New(%s) error = %v", name, err)
+				}
+				out, err := f.Format(ctx, vm)
+				if err != nil {
+					t.Fatalf("Format() error = %v", err)
+				}
+				chrome := strings.ReplaceAll(out, dream.QualityPersistence, "")
+				for _, r := range chrome {
+					if unicode.Is(unicode.Cyrillic, r) {
+						t.Fatalf("found untranslated text %q in output:\n%s", r, out)
+					}
+				}
+			})
+		}
+	}
+}
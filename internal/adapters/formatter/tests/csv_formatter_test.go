@@ -0,0 +1,199 @@
+package tests
+
+import (
+	"context"
+	"encoding/csv"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/xeniasokk/field-switcher/internal/adapters/formatter"
+	"github.com/xeniasokk/field-switcher/internal/adapters/presenter"
+	"github.com/xeniasokk/field-switcher/internal/domain/dream"
+	"github.com/xeniasokk/field-switcher/internal/ports"
+	appErrors "github.com/xeniasokk/field-switcher/pkg/errors"
+)
+
+func TestCSVFormatterFormatAll(t *testing.T) {
+	ctx := context.Background()
+	child, err := dream.NewDefaultFootballerDream()
+	if err != nil {
+		t.Fatalf("failed to create default footballer dream: %v", err)
+	}
+	f, _ := dream.NewField("Dev", "Team")
+	lead, _ := dream.NewAdult("Тимлид", "Desc", f, []string{"Go", "SQL"}, child.Qualities()[:2], "comment")
+	tricky, _ := dream.NewAdult(`Lead, "Platform"`, "Desc", f, []string{"CI/CD"}, nil, "line one\nline two")
+	vms := []ports.ViewModel{
+		presenter.NewConsoleViewModel("title", child, lead, "note"),
+		presenter.NewConsoleViewModel("title", child, tricky, `say "hi", then go`),
+	}
+	traits := child.Qualities()[0].Name() + "; " + child.Qualities()[1].Name()
+
+	tests := []struct {
+		name      string
+		newFunc   func(opts ...formatter.CSVOption) (*formatter.CSVFormatter, error)
+		opts      []formatter.CSVOption
+		delimiter rune
+		want      [][]string
+	}{
+		{
+			name:      "default columns with header",
+			newFunc:   formatter.NewCSVFormatter,
+			delimiter: ',',
+			want: [][]string{
+				{"type", "display_name", "role_title", "stack", "traits", "note"},
+				{"footballer", child.DisplayName(), "Тимлид", "Go; SQL", traits, "note"},
+				{"footballer", child.DisplayName(), `Lead, "Platform"`, "CI/CD", "", `say "hi", then go`},
+			},
+		},
+		{
+			name:    "tsv with custom columns and list separator",
+			newFunc: formatter.NewTSVFormatter,
+			opts: []formatter.CSVOption{
+				formatter.WithColumns(formatter.ColumnRoleTitle, formatter.ColumnStack, formatter.ColumnComment),
+				formatter.WithListSeparator("|"),
+			},
+			delimiter: '\t',
+			want: [][]string{
+				{"role_title", "stack", "comment"},
+				{"Тимлид", "Go|SQL", "comment"},
+				{`Lead, "Platform"`, "CI/CD", "line one\nline two"},
+			},
+		},
+		{
+			name:      "without header",
+			newFunc:   formatter.NewCSVFormatter,
+			opts:      []formatter.CSVOption{formatter.WithColumns(formatter.ColumnRoleTitle), formatter.WithoutHeader()},
+			delimiter: ',',
+			want:      [][]string{{"Тимлид"}, {`Lead, "Platform"`}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cf, err := tt.newFunc(tt.opts...)
+			if err != nil {
+				t.Fatalf("constructor error = %v", err)
+			}
+			out, err := cf.FormatAll(ctx, vms)
+			if err != nil {
+				t.Fatalf("FormatAll() error = %v", err)
+			}
+
+			r := csv.NewReader(strings.NewReader(out))
+			r.Comma = tt.delimiter
+			got, err := r.ReadAll()
+			if err != nil {
+				t.Fatalf("output is not valid csv: %v\n%s", err, out)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected %d records, got %d:\n%s", len(tt.want), len(got), out)
+			}
+			for i := range tt.want {
+				if strings.Join(got[i], "\x00") != strings.Join(tt.want[i], "\x00") {
+					t.Fatalf("record %d = %q, want %q", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCSVFormatterEscapesFormulas(t *testing.T) {
+	child, err := dream.NewDefaultFootballerDream()
+	if err != nil {
+		t.Fatalf("failed to create default footballer dream: %v", err)
+	}
+	f, _ := dream.NewField("Dev", "Team")
+	adult, _ := dream.NewAdult(`=HYPERLINK("http://x")`, "Desc", f, []string{"+1", "Go"}, nil, "@SUM(A1)")
+	vm := presenter.NewConsoleViewModel("title", child, adult, "-2")
+	columns := formatter.WithColumns(formatter.ColumnRoleTitle, formatter.ColumnStack, formatter.ColumnComment, formatter.ColumnNote)
+
+	tests := []struct {
+		name string
+		opts []formatter.CSVOption
+		want []string
+	}{
+		{
+			name: "formulas are prefixed by default",
+			opts: []formatter.CSVOption{columns},
+			want: []string{`'=HYPERLINK("http://x")`, "'+1; Go", "'@SUM(A1)", "'-2"},
+		},
+		{
+			name: "escaping can be turned off",
+			opts: []formatter.CSVOption{columns, formatter.WithoutFormulaEscaping()},
+			want: []string{`=HYPERLINK("http://x")`, "+1; Go", "@SUM(A1)", "-2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cf, err := formatter.NewCSVFormatter(tt.opts...)
+			if err != nil {
+				t.Fatalf("constructor error = %v", err)
+			}
+			out, err := cf.Format(context.Background(), vm)
+			if err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+			got, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+			if err != nil {
+				t.Fatalf("output is not valid csv: %v\n%s", err, out)
+			}
+			if !reflect.DeepEqual(got[1], tt.want) {
+				t.Fatalf("row = %q, want %q", got[1], tt.want)
+			}
+		})
+	}
+}
+
+func TestNewCSVFormatterValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []formatter.CSVOption
+	}{
+		{name: "unknown column", opts: []formatter.CSVOption{formatter.WithColumns("salary")}},
+		{name: "no columns", opts: []formatter.CSVOption{formatter.WithColumns()}},
+		{name: "quote delimiter", opts: []formatter.CSVOption{formatter.WithDelimiter('"')}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := formatter.NewCSVFormatter(tt.opts...); !appErrors.IsCode(err, appErrors.CodeValidation) {
+				t.Fatalf("expected validation error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestParseCSVColumns(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []formatter.CSVColumn
+		wantErr bool
+	}{
+		{name: "list", input: "type,display_name", want: []formatter.CSVColumn{formatter.ColumnType, formatter.ColumnDisplayName}},
+		{name: "spaces around names", input: " stack , traits", want: []formatter.CSVColumn{formatter.ColumnStack, formatter.ColumnTraits}},
+		{name: "unknown column", input: "type,salary", wantErr: true},
+		{name: "empty name", input: "type,", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := formatter.ParseCSVColumns(tt.input)
+			if tt.wantErr {
+				if !appErrors.IsCode(err, appErrors.CodeValidation) {
+					t.Fatalf("expected validation error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseCSVColumns() error = %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ParseCSVColumns() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,276 @@
+package tests
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xeniasokk/field-switcher/internal/adapters/formatter"
+	"github.com/xeniasokk/field-switcher/internal/ports"
+	"github.com/xeniasokk/field-switcher/pkg/document"
+)
+
+// documentViewModel отдаёт только документ, собранный вручную, без презентера.
+type documentViewModel struct {
+	doc document.Document
+}
+
+func (vm documentViewModel) Document() document.Document { return vm.doc }
+
+func TestFormattersRenderArbitraryDocument(t *testing.T) {
+	vm := documentViewModel{doc: document.Document{
+		Title: document.Plain("Отчёт"),
+		Sections: []document.Section{{
+			Key: "hobbies",
+			Blocks: []document.Block{
+				document.Fields{{Label: "city", Value: document.Plain("Казань")}},
+				document.List{Label: "tools", Items: []document.Item{
+					{Name: document.Emphasized("Гитара", document.EmphasisHighlight), Detail: document.Plain("по вечерам")},
+				}},
+			},
+		}},
+		Notes:  []document.Text{document.Plain("Итог")},
+		Quotes: []document.Quote{{Label: "comment", Text: document.Plain("Цитата")}},
+	}}
+	html, err := formatter.NewHTMLFormatter()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		formatter ports.FormatterPort
+		contains  []string
+	}{
+		{
+			name:      "text",
+			formatter: formatter.NewTextFormatter(formatter.WithColor(false)),
+			contains:  []string{"Отчёт", "HOBBIES", "Казань", "Гитара", "по вечерам", "Итог", "Цитата"},
+		},
+		{
+			name:      "markdown",
+			formatter: formatter.NewMarkdownFormatter(),
+			contains:  []string{"# Отчёт", "## hobbies", "**Гитара**", "_Итог_", "> Цитата"},
+		},
+		{
+			name:      "html",
+			formatter: html,
+			contains:  []string{`class="hobbies"`, `<span class="highlight">Гитара</span>`, "Цитата"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := tt.formatter.Format(context.Background(), vm)
+			if err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+			for _, want := range tt.contains {
+				if !strings.Contains(out, want) {
+					t.Errorf("output does not contain %q:\n%s", want, out)
+				}
+			}
+		})
+	}
+}
+
+// reportDocument собран вручную по ключам каталога — так, как его собрал бы presenter.
+func reportDocument() document.Document {
+	key := func(k formatter.MessageKey) string { return string(k) }
+	field := func(name, env string) document.Text {
+		return append(document.Plain(name), document.Span{
+			Text: env, Emphasis: document.EmphasisMuted, Label: key(formatter.MsgEnvironment),
+		})
+	}
+	source := func(typ, name, role string) document.Item {
+		return document.Item{
+			Name:   document.Plain(name),
+			Detail: document.Plain(role),
+			Attrs:  map[string]string{document.AttrType: typ},
+			Blocks: []document.Block{document.Fields{
+				{Label: key(formatter.MsgName), Value: document.Plain(name)},
+				{Label: key(formatter.MsgRole), Value: document.Plain(role)},
+			}},
+		}
+	}
+	patience := document.Item{
+		Name:   document.Emphasized("Терпение", document.EmphasisHighlight),
+		Detail: document.Plain("ждёт результата"),
+		Refs:   []string{"Тренер"},
+		Weight: 4,
+	}
+
+	return document.Document{
+		Title: document.Plain("Отчёт о переходе"),
+		Sections: []document.Section{
+			{
+				Key: key(formatter.MsgSources),
+				Blocks: []document.Block{document.List{Label: key(formatter.MsgDreams), Items: []document.Item{
+					source("coach", "Тренер", "Главный тренер"),
+					source("referee", "Судья", "Арбитр"),
+				}}},
+			},
+			{
+				Key:   key(formatter.MsgChildhood),
+				Attrs: map[string]string{document.AttrType: "coach"},
+				Blocks: []document.Block{
+					document.Fields{
+						{Label: key(formatter.MsgName), Value: document.Plain("Тренер")},
+						{Label: key(formatter.MsgRole), Value: document.Plain("Главный тренер")},
+						{Label: key(formatter.MsgField), Value: field("Стадион", "трибуны")},
+					},
+					document.List{Label: key(formatter.MsgQualities), Items: []document.Item{
+						patience,
+						{Name: document.Emphasized("Тактика", document.EmphasisTerm), Detail: document.Plain("видит игру"), Weight: 2},
+					}},
+				},
+			},
+			{
+				Key: key(formatter.MsgAdult),
+				Blocks: []document.Block{
+					document.Fields{
+						{Label: key(formatter.MsgRole), Value: document.Plain("Тимлид")},
+						{Label: key(formatter.MsgDescription), Value: document.Plain("ведёт команду")},
+						{Label: key(formatter.MsgField), Value: field("Офис", "open space")},
+					},
+					document.List{Label: key(formatter.MsgStack), Items: []document.Item{
+						{Name: document.Emphasized("Go", document.EmphasisCode)},
+						{Name: document.Emphasized("Kubernetes", document.EmphasisHighlight)},
+					}},
+					document.List{Label: key(formatter.MsgTraits), Items: []document.Item{patience}},
+				},
+			},
+		},
+		Notes:  []document.Text{document.Plain("Первая заметка"), document.Plain("Вторая")},
+		Quotes: []document.Quote{{Label: key(formatter.MsgComment), Text: document.Plain("Всё получится")}},
+	}
+}
+
+func TestFormattersRenderHandBuiltReport(t *testing.T) {
+	vm := documentViewModel{doc: reportDocument()}
+	csvFormatter, err := formatter.NewCSVFormatter(formatter.WithColumns(
+		formatter.ColumnType, formatter.ColumnDisplayName, formatter.ColumnDesiredRole, formatter.ColumnRoleTitle,
+		formatter.ColumnField, formatter.ColumnStack, formatter.ColumnQualities, formatter.ColumnTraits,
+		formatter.ColumnSources, formatter.ColumnNote, formatter.ColumnComment,
+	))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	graph, err := formatter.NewGraphFormatter(formatter.GraphDOT)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tmpl, err := formatter.NewTemplateFormatter(
+		`{{ .childhood.type }}|{{ .adult.role_title }}|{{ len .sources }}|{{ (index .adult.traits 0).intensity }}|{{ (index .adult.traits 0).highlighted }}`,
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		formatter ports.FormatterPort
+		contains  []string
+	}{
+		{
+			name:      "json",
+			formatter: formatter.NewJSONFormatter(),
+			contains: []string{
+				`"title":"Отчёт о переходе"`,
+				`"childhood":{"type":"coach","display_name":"Тренер","desired_role":"Главный тренер","field":{"name":"Стадион","environment":"трибуны"}`,
+				`{"name":"Терпение","description":"ждёт результата","intensity":4,"sources":["Тренер"],"highlighted":true}`,
+				`{"name":"Тактика","description":"видит игру","intensity":2,"highlighted":false}`,
+				`"sources":[{"type":"coach"`,
+				`{"type":"referee","display_name":"Судья","desired_role":"Арбитр"`,
+				`"role_title":"Тимлид","role_description":"ведёт команду","field":{"name":"Офис","environment":"open space"}`,
+				`"stack":["Go","Kubernetes"]`,
+				`"note":"Первая заметка | Вторая"`,
+				`"comment":"Всё получится"`,
+			},
+		},
+		{
+			name:      "yaml",
+			formatter: formatter.NewYAMLFormatter(),
+			contains:  []string{"  type: coach\n", "  role_title: Тимлид\n", "    intensity: 4\n", "    highlighted: true\n", "    environment: open space\n"},
+		},
+		{
+			name:      "csv",
+			formatter: csvFormatter,
+			contains: []string{
+				"coach,Тренер,Главный тренер,Тимлид,Офис,Go; Kubernetes,Терпение; Тактика,Терпение,Тренер; Судья,Первая заметка | Вторая,Всё получится\n",
+			},
+		},
+		{
+			name:      "svg",
+			formatter: formatter.NewSVGFormatter(),
+			contains:  []string{">Тренер<", ">Главный тренер<", `fill="#ff6b6b">Терпение<`, ">Тимлид<", ">Офис<", ">Kubernetes<", ">Отчёт о переходе<"},
+		},
+		{
+			name:      "graph",
+			formatter: graph,
+			contains: []string{
+				`[label="Тренер\nГлавный тренер"`,
+				`[label="Тимлид"`,
+				`[label="Терпение", shape=ellipse, color="#ff6b6b", penwidth=2]`,
+				`[label="Kubernetes", shape=note, color="#d19ad8", color="#ff6b6b", penwidth=2]`,
+			},
+		},
+		{
+			name:      "template",
+			formatter: tmpl,
+			contains:  []string{"coach|Тимлид|2|4|true"},
+		},
+		{
+			name:      "markdown front matter",
+			formatter: formatter.NewMarkdownFormatter(formatter.WithFrontMatter()),
+			contains:  []string{"title: Отчёт о переходе\n", "dream_type: coach\n", "dream: Тренер\n", "role: Тимлид\n", "  - Kubernetes\n"},
+		},
+		{
+			name:      "side by side",
+			formatter: formatter.NewTextFormatter(formatter.WithColor(false), formatter.WithLayout(formatter.LayoutSideBySide)),
+			contains:  []string{"Тренер", "→ Тимлид", "Стадион (трибуны)", "Офис (open space)", "• Терпение [Тренер] → • Терпение [Тренер]", "• Тактика             не сохранено", "• Kubernetes"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := tt.formatter.Format(context.Background(), vm)
+			if err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+			for _, want := range tt.contains {
+				if !strings.Contains(out, want) {
+					t.Errorf("output does not contain %q:\n%s", want, out)
+				}
+			}
+		})
+	}
+}
+
+// Двоичные форматы не разобрать на строки, поэтому проверяется, что содержимое документа
+// вообще доходит до картинки: карточка отчёта отличается от карточки пустого документа.
+func TestBinaryFormattersRenderHandBuiltReport(t *testing.T) {
+	ctx := context.Background()
+	png, err := formatter.NewPNGFormatter()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pdf := formatter.NewPDFFormatter(formatter.WithIssuedAt(time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)))
+
+	for name, f := range map[string]ports.FormatterPort{"png": png, "pdf": pdf} {
+		t.Run(name, func(t *testing.T) {
+			report, err := f.Format(ctx, documentViewModel{doc: reportDocument()})
+			if err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+			empty, err := f.Format(ctx, documentViewModel{})
+			if err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+			if report == empty {
+				t.Errorf("%s output ignores the document", name)
+			}
+		})
+	}
+}
@@ -59,3 +59,31 @@ func TestTextFormatterFormat(t *testing.T) {
 		})
 	}
 }
+
+func TestTextFormatterFormatSources(t *testing.T) {
+	ctx := context.Background()
+	footballer, err := dream.NewDefaultFootballerDream()
+	if err != nil {
+		t.Fatalf("failed to create default footballer dream: %v", err)
+	}
+	f, _ := dream.NewField("Космос", "Орбита")
+	q, _ := dream.NewQuality("Любопытство", "Звёзды")
+	astronaut, err := dream.NewChildhoodDream(dream.TypeFootballer, "Космонавт", "Пилот", f, []dream.Quality{q})
+	if err != nil {
+		t.Fatalf("failed to create dream: %v", err)
+	}
+	a, _ := dream.NewAdult("Role", "Desc", f, nil, []dream.Quality{q.WithSources("Космонавт")}, "")
+
+	vm := presenter.NewConsoleViewModel("title", footballer, a, "").
+		WithSources([]dream.ChildhoodDream{footballer, astronaut})
+
+	text, err := formatter.NewTextFormatter().Format(ctx, vm)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{"ИСХОДНЫЕ МЕЧТЫ", "Футболист", "Космонавт", "[Космонавт]"} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected text to contain '%s'", want)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package tests
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/xeniasokk/field-switcher/internal/adapters/formatter"
+	"github.com/xeniasokk/field-switcher/internal/adapters/presenter"
+	"github.com/xeniasokk/field-switcher/internal/domain/dream"
+	"github.com/xeniasokk/field-switcher/internal/ports"
+	appErrors "github.com/xeniasokk/field-switcher/pkg/errors"
+)
+
+func TestGraphFormatterFormat(t *testing.T) {
+	ctx := context.Background()
+	child, err := dream.NewDefaultFootballerDream()
+	if err != nil {
+		t.Fatalf("failed to create default footballer dream: %v", err)
+	}
+	f, _ := dream.NewField("Dev", "Team")
+	adult, _ := dream.NewAdult(`Lead "Platform"`, "Desc", f, []string{"Go", "C#"}, child.Qualities(), "comment")
+	vm := presenter.NewConsoleViewModel("title", child, adult, "note").WithHighlights(dream.QualityPersistence)
+
+	tests := []struct {
+		name     string
+		syntax   formatter.GraphSyntax
+		contains []string
+	}{
+		{
+			name:   "dot",
+			syntax: formatter.GraphDOT,
+			contains: []string{
+				"digraph dreams {",
+				"subgraph cluster_childhood",
+				`label="Детская мечта"`,
+				`role1 [label="Lead \"Platform\""`,
+				`s2 [label="C#"`,
+				"dream1 -> q1;",
+				"q1 -> t1;",
+				"t1 -> role1;",
+				"role1 -> s1;",
+				`color="#ff6b6b"`,
+			},
+		},
+		{
+			name:   "mermaid",
+			syntax: formatter.GraphMermaid,
+			contains: []string{
+				"flowchart LR",
+				`subgraph childhood["Детская мечта"]`,
+				`role1("Lead #quot;Platform#quot;")`,
+				`s2[/"C#35;"/]`,
+				"dream1 --> q1",
+				"role1 --> s1",
+				"class t",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := formatter.NewGraphFormatter(tt.syntax)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			out, err := g.Format(ctx, vm)
+			if err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+			for _, want := range tt.contains {
+				if !strings.Contains(out, want) {
+					t.Errorf("output does not contain %q:\n%s", want, out)
+				}
+			}
+		})
+	}
+}
+
+func TestGraphFormatterFormatAllTeam(t *testing.T) {
+	ctx := context.Background()
+	child, err := dream.NewDefaultFootballerDream()
+	if err != nil {
+		t.Fatalf("failed to create default footballer dream: %v", err)
+	}
+	f, _ := dream.NewField("Dev", "Team")
+	first, _ := dream.NewAdult("Тимлид", "Desc", f, []string{"Go", "SQL"}, child.Qualities()[:1], "")
+	second, _ := dream.NewAdult("Тимлид", "Desc", f, []string{"Go", "Kafka"}, child.Qualities()[:1], "")
+	vms := []ports.ViewModel{
+		presenter.NewConsoleViewModel("title", child, first, "note"),
+		// Go выделен только у второго участника, но общий узел должен остаться выделенным
+		presenter.NewConsoleViewModel("title", child, second, "note").WithHighlights("Go"),
+	}
+
+	g, err := formatter.NewGraphFormatter(formatter.GraphDOT)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out, err := g.FormatAll(ctx, vms)
+	if err != nil {
+		t.Fatalf("FormatAll() error = %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		want  int
+	}{
+		{name: "each member has own dream node", token: "dream2 [", want: 1},
+		{name: "shared role is a single node", token: `[label="Тимлид"`, want: 1},
+		{name: "shared stack item is a single node", token: `[label="Go"`, want: 1},
+		{name: "distinct stack items are kept", token: "s3 [", want: 1},
+		{name: "duplicate edges are merged", token: "role1 -> s1;", want: 1},
+		{name: "merged node keeps any member highlight", token: `[label="Go", shape=note, color="#d19ad8", color="#ff6b6b"`, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := strings.Count(out, tt.token); got != tt.want {
+				t.Errorf("count of %q = %d, want %d:\n%s", tt.token, got, tt.want, out)
+			}
+		})
+	}
+}
+
+func TestNewGraphFormatterUnknownSyntax(t *testing.T) {
+	_, err := formatter.NewGraphFormatter("plantuml")
+	if !appErrors.IsCode(err, appErrors.CodeValidation) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
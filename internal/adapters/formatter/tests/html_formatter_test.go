@@ -0,0 +1,90 @@
+package tests
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/xeniasokk/field-switcher/internal/adapters/formatter"
+	"github.com/xeniasokk/field-switcher/internal/adapters/presenter"
+	"github.com/xeniasokk/field-switcher/internal/domain/dream"
+)
+
+func TestHTMLFormatterFormat(t *testing.T) {
+	ctx := context.Background()
+	f, _ := dream.NewField("Поле", "Стадион & трибуны")
+	persistence, _ := dream.NewQuality(dream.QualityPersistence, "Не сдаваться")
+	spirit, _ := dream.NewQuality("Командный дух", `"Вместе" <b>`)
+
+	tests := []struct {
+		name         string
+		displayName  string
+		opts         []formatter.HTMLOption
+		wantContains []string
+		wantMissing  []string
+	}{
+		{
+			name:        "sections and persistence highlight",
+			displayName: "Футболист",
+			wantContains: []string{
+				"<!DOCTYPE html>",
+				"<h2>ДЕТСКАЯ МЕЧТА</h2>",
+				"<h2>ВЗРОСЛАЯ РОЛЬ</h2>",
+				`<li><span class="highlight">Упорство</span>`,
+				`<li><span class="term">Командный дух</span>`,
+				"--bg: #14161a;",
+			},
+		},
+		{
+			name:        "user strings are escaped",
+			displayName: `<script>alert("x")</script>`,
+			wantContains: []string{
+				"&lt;script&gt;alert(&#34;x&#34;)&lt;/script&gt;",
+				"&#34;Вместе&#34; &lt;b&gt;",
+				"Стадион &amp; трибуны",
+			},
+			wantMissing: []string{"<script>", "<b>"},
+		},
+		{
+			name:         "light theme",
+			displayName:  "Футболист",
+			opts:         []formatter.HTMLOption{formatter.WithHTMLTheme(formatter.HTMLThemeLight)},
+			wantContains: []string{"--bg: #f6f7f9;"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			child, err := dream.NewChildhoodDream(
+				dream.TypeFootballer, tt.displayName, "Игрок", f, []dream.Quality{spirit, persistence},
+			)
+			if err != nil {
+				t.Fatalf("failed to create dream: %v", err)
+			}
+			a, err := dream.NewAdult("Role", "Desc", f, []string{"Go"}, child.Qualities(), "comment")
+			if err != nil {
+				t.Fatalf("failed to create adult: %v", err)
+			}
+			vm := presenter.NewConsoleViewModel("title", child, a, "note").WithHighlights(dream.QualityPersistence)
+
+			hf, err := formatter.NewHTMLFormatter(tt.opts...)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			text, err := hf.Format(ctx, vm)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for _, want := range tt.wantContains {
+				if !strings.Contains(text, want) {
+					t.Fatalf("expected HTML to contain %q, got:\n%s", want, text)
+				}
+			}
+			for _, missing := range tt.wantMissing {
+				if strings.Contains(text, missing) {
+					t.Fatalf("expected HTML not to contain %q", missing)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/xeniasokk/field-switcher/internal/adapters/formatter"
+	"github.com/xeniasokk/field-switcher/internal/adapters/presenter"
+	"github.com/xeniasokk/field-switcher/internal/domain/dream"
+)
+
+func TestJSONFormatterFormat(t *testing.T) {
+	ctx := context.Background()
+	child, err := dream.NewDefaultFootballerDream()
+	if err != nil {
+		t.Fatalf("failed to create default footballer dream: %v", err)
+	}
+	f, _ := dream.NewField("Dev", "Team")
+	a, err := dream.NewAdult("Role", "Desc <&>", f, []string{"Go"}, child.Qualities(), "comment")
+	if err != nil {
+		t.Fatalf("failed to create adult: %v", err)
+	}
+	vm := presenter.NewConsoleViewModel("title", child, a, "note")
+
+	tests := []struct {
+		name        string
+		opts        []formatter.JSONOption
+		wantNewline int
+	}{
+		{
+			name:        "compact",
+			wantNewline: 1,
+		},
+		{
+			name:        "pretty",
+			opts:        []formatter.JSONOption{formatter.WithPrettyPrint("  ")},
+			wantNewline: 10,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, err := formatter.NewJSONFormatter(tt.opts...).Format(ctx, vm)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if n := strings.Count(text, "\n"); n < tt.wantNewline {
+				t.Fatalf("expected at least %d lines, got %d", tt.wantNewline, n)
+			}
+			if !strings.Contains(text, "Desc <&>") {
+				t.Fatalf("expected HTML characters to stay unescaped")
+			}
+
+			var doc formatter.JSONDocument
+			if err := json.Unmarshal([]byte(text), &doc); err != nil {
+				t.Fatalf("output is not valid JSON: %v", err)
+			}
+			if doc.SchemaVersion != formatter.JSONSchemaVersion {
+				t.Fatalf("expected schema version %s, got %s", formatter.JSONSchemaVersion, doc.SchemaVersion)
+			}
+			if doc.Childhood.DisplayName != dream.FootballerDisplayName {
+				t.Fatalf("unexpected childhood display name %q", doc.Childhood.DisplayName)
+			}
+			if len(doc.Adult.Traits) != len(child.Qualities()) {
+				t.Fatalf("expected %d traits, got %d", len(child.Qualities()), len(doc.Adult.Traits))
+			}
+			if doc.Note != "note" || doc.Comment != "comment" || doc.Title != "title" {
+				t.Fatalf("unexpected document: %+v", doc)
+			}
+		})
+	}
+}
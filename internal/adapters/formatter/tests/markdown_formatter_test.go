@@ -0,0 +1,89 @@
+package tests
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/xeniasokk/field-switcher/internal/adapters/formatter"
+	"github.com/xeniasokk/field-switcher/internal/adapters/presenter"
+	"github.com/xeniasokk/field-switcher/internal/domain/dream"
+)
+
+func TestMarkdownFormatterFormat(t *testing.T) {
+	ctx := context.Background()
+	f, _ := dream.NewField("Поле", "Стадион")
+	q, _ := dream.NewQuality("Упорство", "Не *сдаваться*")
+
+	tests := []struct {
+		name         string
+		displayName  string
+		opts         []formatter.MarkdownOption
+		wantContains []string
+		wantMissing  []string
+	}{
+		{
+			name:        "sections and lists",
+			displayName: "Футболист",
+			wantContains: []string{
+				"# title\n",
+				"## Детская мечта\n",
+				"- **Название:** Футболист\n",
+				"## Взрослая роль\n",
+				"### Стек\n\n- `Go`\n- `` C` ``\n",
+				"- **Упорство** — Не \\*сдаваться\\*\n",
+				"> первая строка\n>\n> вторая\n",
+			},
+			wantMissing: []string{"---\n"},
+		},
+		{
+			name:        "user names are escaped",
+			displayName: "#1 _star_ [link](x) <b>",
+			wantContains: []string{
+				"- **Название:** \\#1 \\_star\\_ \\[link\\](x) \\<b\\>\n",
+			},
+		},
+		{
+			name:         "entities are escaped",
+			displayName:  "&lt;b&gt; & co",
+			wantContains: []string{"- **Название:** \\&lt;b\\&gt; \\& co\n"},
+		},
+		{
+			name:        "front matter",
+			displayName: "Футболист",
+			opts:        []formatter.MarkdownOption{formatter.WithFrontMatter()},
+			wantContains: []string{
+				"---\ntitle: title\ndream_type: footballer\ndream: Футболист\nrole: Role\nstack:\n  - Go\n",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			child, err := dream.NewChildhoodDream(dream.TypeFootballer, tt.displayName, "Игрок", f, []dream.Quality{q})
+			if err != nil {
+				t.Fatalf("failed to create dream: %v", err)
+			}
+			a, err := dream.NewAdult("Role", "Desc", f, []string{"Go", "C`"}, []dream.Quality{q}, "первая строка\n\nвторая")
+			if err != nil {
+				t.Fatalf("failed to create adult: %v", err)
+			}
+			vm := presenter.NewConsoleViewModel("title", child, a, "note").WithHighlights(dream.QualityPersistence)
+
+			text, err := formatter.NewMarkdownFormatter(tt.opts...).Format(ctx, vm)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for _, want := range tt.wantContains {
+				if !strings.Contains(text, want) {
+					t.Fatalf("expected markdown to contain %q, got:\n%s", want, text)
+				}
+			}
+			for _, missing := range tt.wantMissing {
+				if strings.Contains(text, missing) {
+					t.Fatalf("expected markdown not to contain %q", missing)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package tests
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"errors"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xeniasokk/field-switcher/internal/adapters/formatter"
+	"github.com/xeniasokk/field-switcher/internal/adapters/presenter"
+	"github.com/xeniasokk/field-switcher/internal/domain/dream"
+	appErrors "github.com/xeniasokk/field-switcher/pkg/errors"
+)
+
+type brokenWriter struct{}
+
+func (brokenWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestPDFFormatterFormat(t *testing.T) {
+	ctx := context.Background()
+	child, err := dream.NewDefaultFootballerDream()
+	if err != nil {
+		t.Fatalf("failed to create default footballer dream: %v", err)
+	}
+	f, _ := dream.NewField("Разработка", "Команда")
+	adult, _ := dream.NewAdult("Тимлид", "Desc", f, []string{"Go", "SQL"}, child.Qualities(), strings.Repeat("Длинный комментарий. ", 60))
+	vm := presenter.NewConsoleViewModel("Переход", child, adult, "note")
+	en, _ := formatter.NewCatalog(formatter.LocaleEN)
+	issued := time.Date(2026, 9, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		opts     []formatter.PDFOption
+		contains []string
+	}{
+		{
+			name: "default locale",
+			opts: []formatter.PDFOption{formatter.WithIssuedAt(issued)},
+			contains: []string{
+				"%PDF-",
+				"/MediaBox [0 0 595.28 841.89]",
+				"/FontFile2",
+				"/ToUnicode",
+				"/Lang (ru)",
+			},
+		},
+		{
+			name:     "english catalog",
+			opts:     []formatter.PDFOption{formatter.WithPDFCatalog(en), formatter.WithIssuedAt(issued)},
+			contains: []string{"/Lang (en)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := formatter.NewPDFFormatter(tt.opts...)
+			out, err := p.Format(ctx, vm)
+			if err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+			for _, want := range tt.contains {
+				if !strings.Contains(out, want) {
+					t.Errorf("pdf does not contain %q", want)
+				}
+			}
+			if got := strings.Count(out, "/Type /Page\n"); got != 1 {
+				t.Errorf("page count = %d, want 1", got)
+			}
+
+			again, err := p.Format(ctx, vm)
+			if err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+			if again != out {
+				t.Errorf("output is not deterministic with a fixed issue date")
+			}
+		})
+	}
+}
+
+func TestPDFFormatterFormatToWriteError(t *testing.T) {
+	child, err := dream.NewDefaultFootballerDream()
+	if err != nil {
+		t.Fatalf("failed to create default footballer dream: %v", err)
+	}
+	f, _ := dream.NewField("Dev", "Team")
+	adult, _ := dream.NewAdult("Тимлид", "Desc", f, []string{"Go"}, nil, "")
+	vm := presenter.NewConsoleViewModel("title", child, adult, "note")
+
+	err = formatter.NewPDFFormatter().FormatTo(context.Background(), brokenWriter{}, vm)
+	if !appErrors.IsCode(err, appErrors.CodeIO) {
+		t.Fatalf("expected IO error, got %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := formatter.NewPDFFormatter().FormatTo(context.Background(), &buf, vm); err != nil {
+		t.Fatalf("FormatTo() error = %v", err)
+	}
+	if !bytes.HasPrefix(buf.Bytes(), []byte("%PDF-")) {
+		t.Fatalf("expected pdf header, got %q", buf.Bytes()[:8])
+	}
+}
+
+func TestPDFFormatterLongContentStaysAboveFooter(t *testing.T) {
+	child, err := dream.NewDefaultFootballerDream()
+	if err != nil {
+		t.Fatalf("failed to create default footballer dream: %v", err)
+	}
+	f, _ := dream.NewField(strings.Repeat("Очень длинное поле ", 10), strings.Repeat("Среда ", 10))
+	stack := strings.Fields(strings.Repeat("Kubernetes PostgreSQL Terraform ", 15))
+	adult, _ := dream.NewAdult(strings.Repeat("Руководитель направления ", 6), "Desc", f, stack,
+		child.Qualities(), strings.Repeat("Длинный комментарий. ", 60))
+	vm := presenter.NewConsoleViewModel("Переход", child, adult, "note")
+	issued := time.Date(2026, 9, 1, 10, 0, 0, 0, time.UTC)
+
+	out, err := formatter.NewPDFFormatter(formatter.WithIssuedAt(issued)).Format(context.Background(), vm)
+	if err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+
+	// строки рисуются сверху вниз, подпись последней: в координатах PDF (ось Y вверх)
+	// каждая следующая строка должна быть ниже предыдущей
+	rows := pdfTextRows(t, out)
+	if len(rows) < 10 {
+		t.Fatalf("found %d text rows, expected the whole certificate", len(rows))
+	}
+	for i := 1; i < len(rows); i++ {
+		if rows[i] >= rows[i-1] {
+			t.Fatalf("row %d at y=%.2f is not below row %d at y=%.2f: content overlaps the footer", i, rows[i], i-1, rows[i-1])
+		}
+	}
+}
+
+var (
+	pdfStream       = regexp.MustCompile(`(?s)stream\n(.*?)\nendstream`)
+	pdfTextPosition = regexp.MustCompile(`BT [0-9.]+ ([0-9.]+) Td`)
+)
+
+// pdfTextRows распаковывает потоки страницы и возвращает высоты строк текста в порядке отрисовки.
+func pdfTextRows(t *testing.T, pdf string) []float64 {
+	t.Helper()
+	var rows []float64
+	for _, m := range pdfStream.FindAllStringSubmatch(pdf, -1) {
+		r, err := zlib.NewReader(strings.NewReader(m[1]))
+		if err != nil {
+			continue
+		}
+		data, err := io.ReadAll(r)
+		if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+			continue
+		}
+		for _, m := range pdfTextPosition.FindAllStringSubmatch(string(data), -1) {
+			y, err := strconv.ParseFloat(m[1], 64)
+			if err != nil {
+				t.Fatalf("bad text position %q: %v", m[0], err)
+			}
+			rows = append(rows, y)
+		}
+	}
+	return rows
+}
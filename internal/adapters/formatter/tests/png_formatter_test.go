@@ -0,0 +1,116 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/draw"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/xeniasokk/field-switcher/internal/adapters/formatter"
+	"github.com/xeniasokk/field-switcher/internal/adapters/presenter"
+	"github.com/xeniasokk/field-switcher/internal/domain/dream"
+	appErrors "github.com/xeniasokk/field-switcher/pkg/errors"
+)
+
+func TestPNGFormatterGolden(t *testing.T) {
+	ctx := context.Background()
+	child, err := dream.NewDefaultFootballerDream()
+	if err != nil {
+		t.Fatalf("failed to create default footballer dream: %v", err)
+	}
+	f, _ := dream.NewField("Поле разработки", "Команда")
+
+	tests := []struct {
+		name   string
+		golden string
+		role   string
+		traits []dream.Quality
+	}{
+		{
+			name:   "default team lead",
+			golden: "png_card_default.golden.png",
+			role:   "Тимлид",
+			traits: child.Qualities(),
+		},
+		{
+			name:   "long role without traits falls back to childhood qualities",
+			golden: "png_card_long.golden.png",
+			role:   "Главный архитектор распределённых высоконагруженных систем реального времени и всего остального",
+		},
+	}
+
+	p, err := formatter.NewPNGFormatter()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := dream.NewAdult(tt.role, "Desc", f, []string{"Go"}, tt.traits, "")
+			if err != nil {
+				t.Fatalf("failed to create adult: %v", err)
+			}
+			vm := presenter.NewConsoleViewModel("field-switcher — трансформация мечты", child, a, "note").WithHighlights(dream.QualityPersistence)
+
+			got, err := p.Format(ctx, vm)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			cfg, err := png.DecodeConfig(bytes.NewReader([]byte(got)))
+			if err != nil {
+				t.Fatalf("output is not a png: %v", err)
+			}
+			if cfg.Width != 1200 || cfg.Height != 630 {
+				t.Fatalf("size = %dx%d, want 1200x630", cfg.Width, cfg.Height)
+			}
+
+			path := filepath.Join("testdata", tt.golden)
+			if *update {
+				if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+					t.Fatalf("failed to update golden file: %v", err)
+				}
+			}
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("failed to read golden file: %v", err)
+			}
+			// сравниваются пиксели, а не байты: сжатие PNG может меняться между версиями Go
+			if !bytes.Equal(decodePixels(t, []byte(got)), decodePixels(t, want)) {
+				t.Fatalf("PNG differs from %s; run go test with -update to refresh", path)
+			}
+		})
+	}
+}
+
+func TestPNGFormatterFormatToWriteError(t *testing.T) {
+	child, err := dream.NewDefaultFootballerDream()
+	if err != nil {
+		t.Fatalf("failed to create default footballer dream: %v", err)
+	}
+	f, _ := dream.NewField("Dev", "Team")
+	a, _ := dream.NewAdult("Тимлид", "Desc", f, []string{"Go"}, nil, "")
+	vm := presenter.NewConsoleViewModel("title", child, a, "note")
+
+	p, err := formatter.NewPNGFormatter()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := p.FormatTo(context.Background(), brokenWriter{}, vm); !appErrors.IsCode(err, appErrors.CodeIO) {
+		t.Fatalf("expected IO error, got %v", err)
+	}
+}
+
+func decodePixels(t *testing.T, data []byte) []byte {
+	t.Helper()
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("failed to decode png: %v", err)
+	}
+	rgba := image.NewRGBA(img.Bounds())
+	draw.Draw(rgba, rgba.Bounds(), img, img.Bounds().Min, draw.Src)
+	return rgba.Pix
+}
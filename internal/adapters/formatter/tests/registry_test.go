@@ -0,0 +1,205 @@
+package tests
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/xeniasokk/field-switcher/internal/adapters/formatter"
+	"github.com/xeniasokk/field-switcher/internal/adapters/presenter"
+	"github.com/xeniasokk/field-switcher/internal/domain/dream"
+	"github.com/xeniasokk/field-switcher/internal/ports"
+	appErrors "github.com/xeniasokk/field-switcher/pkg/errors"
+)
+
+func TestRegistryNegotiate(t *testing.T) {
+	registry := formatter.DefaultRegistry()
+
+	tests := []struct {
+		name     string
+		accept   string
+		want     string
+		wantCode appErrors.Code
+	}{
+		{name: "exact type", accept: "application/json", want: formatter.FormatJSON},
+		{name: "alias media type", accept: "application/x-yaml", want: formatter.FormatYAML},
+		{name: "quality wins over order", accept: "text/markdown;q=0.5, text/html", want: formatter.FormatHTML},
+		{name: "header order breaks ties", accept: "text/csv, application/json", want: formatter.FormatCSV},
+		{name: "specific beats wildcard at same q", accept: "text/*, text/html", want: formatter.FormatHTML},
+		{name: "type wildcard", accept: "image/*", want: formatter.FormatSVG},
+		{name: "any type prefers text", accept: "*/*", want: formatter.FormatText},
+		{name: "q=0 excludes formatter", accept: "text/plain;q=0, text/*", want: formatter.FormatAccessible},
+		{name: "q=0 wildcard keeps explicit type", accept: "application/json, */*;q=0", want: formatter.FormatJSON},
+		{name: "q=0 subtype wildcard keeps explicit type", accept: "text/plain, text/*;q=0", want: formatter.FormatText},
+		{name: "browser header", accept: "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8", want: formatter.FormatHTML},
+		{name: "nothing acceptable", accept: "application/msword", wantCode: appErrors.CodeNotFound},
+		{name: "invalid quality", accept: "text/html;q=2", wantCode: appErrors.CodeValidation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reg, err := registry.Negotiate(tt.accept)
+			if tt.wantCode != "" {
+				if !appErrors.IsCode(err, tt.wantCode) {
+					t.Fatalf("expected %s error, got %v", tt.wantCode, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Negotiate() error = %v", err)
+			}
+			if reg.Name != tt.want {
+				t.Fatalf("Negotiate(%q) = %q, want %q", tt.accept, reg.Name, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegistryRegister(t *testing.T) {
+	factory := func(s formatter.Settings) (ports.FormatterPort, error) {
+		return formatter.NewJSONFormatter(), nil
+	}
+
+	tests := []struct {
+		name     string
+		reg      formatter.Registration
+		wantCode appErrors.Code
+	}{
+		{name: "new format", reg: formatter.Registration{Name: "ndjson", MediaTypes: []string{"application/x-ndjson"}, Factory: factory}},
+		{name: "duplicate name", reg: formatter.Registration{Name: formatter.FormatJSON, Factory: factory}, wantCode: appErrors.CodeConflict},
+		{name: "duplicate media type", reg: formatter.Registration{Name: "json2", MediaTypes: []string{"Application/JSON"}, Factory: factory}, wantCode: appErrors.CodeConflict},
+		{name: "missing factory", reg: formatter.Registration{Name: "empty"}, wantCode: appErrors.CodeValidation},
+	}
+
+	registry := formatter.DefaultRegistry()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := registry.Register(tt.reg)
+			if tt.wantCode != "" {
+				if !appErrors.IsCode(err, tt.wantCode) {
+					t.Fatalf("expected %s error, got %v", tt.wantCode, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Register() error = %v", err)
+			}
+			if _, err := registry.New(tt.reg.Name, formatter.Settings{}); err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestDefaultRegistryFactories(t *testing.T) {
+	registry := formatter.DefaultRegistry()
+	child, err := dream.NewDefaultFootballerDream()
+	if err != nil {
+		t.Fatalf("failed to create default footballer dream: %v", err)
+	}
+	f, _ := dream.NewField("Dev", "Team")
+	a, _ := dream.NewAdult("Role", "Desc", f, []string{"Go"}, child.Qualities(), "comment")
+	vm := presenter.NewConsoleViewModel("title", child, a, "note")
+
+	for _, name := range registry.Names() {
+		t.Run(name, func(t *testing.T) {
+			f, err := registry.New(name, formatter.Settings{})
+			if name == formatter.FormatTemplate {
+				if !appErrors.IsCode(err, appErrors.CodeValidation) {
+					t.Fatalf("expected validation error without template file, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			out, err := f.Format(context.Background(), vm)
+			if err != nil || out == "" {
+				t.Fatalf("Format() = %q, %v", out, err)
+			}
+
+			reg, _ := registry.Lookup(name)
+			if _, ok := f.(ports.BatchFormatterPort); ok != reg.Capabilities.Batch {
+				t.Fatalf("batch capability = %v, but formatter implements batch port = %v", reg.Capabilities.Batch, ok)
+			}
+		})
+	}
+
+	if _, err := registry.Lookup("docx"); !appErrors.IsCode(err, appErrors.CodeNotFound) {
+		t.Fatalf("expected not found for unknown format, got %v", err)
+	}
+}
+
+func TestDefaultRegistrySettings(t *testing.T) {
+	registry := formatter.DefaultRegistry()
+	child, err := dream.NewDefaultFootballerDream()
+	if err != nil {
+		t.Fatalf("failed to create default footballer dream: %v", err)
+	}
+	f, _ := dream.NewField("Dev", "Team")
+	a, _ := dream.NewAdult("Role", "Desc", f, []string{"Go", "SQL"}, child.Qualities()[:2], "")
+	vm := presenter.NewConsoleViewModel("title", child, a, "note")
+
+	tests := []struct {
+		name     string
+		format   string
+		settings formatter.Settings
+		want     string
+		wantCode appErrors.Code
+	}{
+		{
+			name:   "defaults",
+			format: formatter.FormatCSV,
+			want:   "type,display_name,role_title,stack,traits,note\n",
+		},
+		{
+			name:   "columns and separator",
+			format: formatter.FormatCSV,
+			settings: formatter.Settings{
+				CSVColumns:       []formatter.CSVColumn{formatter.ColumnRoleTitle, formatter.ColumnStack},
+				CSVListSeparator: " / ",
+			},
+			want: "role_title,stack\nRole,Go / SQL\n",
+		},
+		{
+			name:     "tsv columns",
+			format:   formatter.FormatTSV,
+			settings: formatter.Settings{CSVColumns: []formatter.CSVColumn{formatter.ColumnDisplayName, formatter.ColumnTraits}},
+			want:     "display_name\ttraits\n" + child.DisplayName() + "\t" + child.Qualities()[0].Name() + "; " + child.Qualities()[1].Name() + "\n",
+		},
+		{
+			name:     "markdown front matter",
+			format:   formatter.FormatMarkdown,
+			settings: formatter.Settings{FrontMatter: true},
+			want:     "---\ntitle: title\n",
+		},
+		{
+			name:     "unknown column",
+			format:   formatter.FormatCSV,
+			settings: formatter.Settings{CSVColumns: []formatter.CSVColumn{"salary"}},
+			wantCode: appErrors.CodeValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := registry.New(tt.format, tt.settings)
+			if tt.wantCode != "" {
+				if !appErrors.IsCode(err, tt.wantCode) {
+					t.Fatalf("expected %s error, got %v", tt.wantCode, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			out, err := f.Format(context.Background(), vm)
+			if err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+			if !strings.HasPrefix(out, tt.want) {
+				t.Errorf("Format() = %q, want prefix %q", out, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package tests
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/xeniasokk/field-switcher/internal/adapters/formatter"
+	"github.com/xeniasokk/field-switcher/internal/adapters/presenter"
+	"github.com/xeniasokk/field-switcher/internal/domain/dream"
+)
+
+func TestTextFormatterSideBySide(t *testing.T) {
+	ctx := context.Background()
+	child, err := dream.NewDefaultFootballerDream()
+	if err != nil {
+		t.Fatalf("failed to create default footballer dream: %v", err)
+	}
+	f, _ := dream.NewField("Dev", "Team")
+	// последнее качество не сохранилось во взрослой роли
+	traits := child.Qualities()[:len(child.Qualities())-1]
+	a, _ := dream.NewAdult("Тимлид", "Desc", f, []string{"Go"}, traits, "comment")
+	vm := presenter.NewConsoleViewModel("title", child, a, "note")
+	lastQuality := child.Qualities()[len(child.Qualities())-1].Name()
+
+	tests := []struct {
+		name         string
+		width        int
+		wantSideBy   bool
+		wantContains []string
+	}{
+		{
+			name:         "natural width",
+			wantSideBy:   true,
+			wantContains: []string{"Футболист", "→ Тимлид", "→ • " + traits[0].Name(), "не сохранено"},
+		},
+		{
+			name:         "fits terminal",
+			width:        80,
+			wantSideBy:   true,
+			wantContains: []string{"→ Тимлид", "не сохранено"},
+		},
+		{
+			name:         "narrow terminal falls back to stacked layout",
+			width:        40,
+			wantContains: []string{"ДЕТСКАЯ МЕЧТА\n", "ВЗРОСЛАЯ РОЛЬ\n", "Сохранённые качества:"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := formatter.NewTextFormatter(
+				formatter.WithColor(false),
+				formatter.WithWidth(tt.width),
+				formatter.WithLayout(formatter.LayoutSideBySide),
+			).Format(ctx, vm)
+			if err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+			for _, want := range tt.wantContains {
+				if !strings.Contains(out, want) {
+					t.Fatalf("expected output to contain %q:\n%s", want, out)
+				}
+			}
+
+			lines := strings.Split(out, "\n")
+			headerIdx := -1
+			for i, line := range lines {
+				if strings.HasPrefix(line, "ДЕТСКАЯ МЕЧТА") && strings.Contains(line, "ВЗРОСЛАЯ РОЛЬ") {
+					headerIdx = i
+				}
+			}
+			if (headerIdx >= 0) != tt.wantSideBy {
+				t.Fatalf("side-by-side header found = %v, want %v:\n%s", headerIdx >= 0, tt.wantSideBy, out)
+			}
+			if !tt.wantSideBy {
+				return
+			}
+
+			// стрелки и правая колонка выровнены по одной позиции
+			column := formatter.DisplayWidth(lines[headerIdx][:strings.Index(lines[headerIdx], "ВЗРОСЛАЯ")])
+			for _, line := range lines {
+				if i := strings.Index(line, "→"); i >= 0 {
+					if got := formatter.DisplayWidth(line[:i]) + 2; got != column {
+						t.Fatalf("arrow in %q is not aligned with column %d", line, column)
+					}
+				}
+				if strings.Contains(line, lastQuality) && strings.Contains(line, "→") {
+					t.Fatalf("lost quality %q must not point to a trait: %q", lastQuality, line)
+				}
+				if tt.width > 0 && formatter.DisplayWidth(line) > tt.width {
+					t.Fatalf("line %q exceeds width %d", line, tt.width)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/xeniasokk/field-switcher/internal/adapters/formatter"
+	"github.com/xeniasokk/field-switcher/internal/adapters/presenter"
+	"github.com/xeniasokk/field-switcher/internal/domain/dream"
+	"github.com/xeniasokk/field-switcher/internal/ports"
+	appErrors "github.com/xeniasokk/field-switcher/pkg/errors"
+)
+
+// limitedWriter принимает первые limit байт, а потом отказывает, как переполненный диск.
+type limitedWriter struct {
+	limit int
+	buf   bytes.Buffer
+}
+
+func (w *limitedWriter) Write(p []byte) (int, error) {
+	if w.buf.Len()+len(p) > w.limit {
+		return 0, errors.New("no space left on device")
+	}
+	return w.buf.Write(p)
+}
+
+func TestStreamFormatTo(t *testing.T) {
+	ctx := context.Background()
+	child, err := dream.NewDefaultFootballerDream()
+	if err != nil {
+		t.Fatalf("failed to create default footballer dream: %v", err)
+	}
+	f, _ := dream.NewField("Dev", "Team")
+	a, _ := dream.NewAdult("Role", "Desc", f, []string{"Go"}, child.Qualities(), "comment")
+	vm := presenter.NewConsoleViewModel("title", child, a, "note")
+	csvFormatter, _ := formatter.NewCSVFormatter()
+
+	tests := []struct {
+		name       string
+		formatter  ports.FormatterPort
+		wantNative bool
+	}{
+		{name: "text", formatter: formatter.NewTextFormatter(formatter.WithColor(false)), wantNative: true},
+		{name: "json", formatter: formatter.NewJSONFormatter(formatter.WithPrettyPrint("  ")), wantNative: true},
+		{name: "csv", formatter: csvFormatter, wantNative: true},
+		{name: "markdown through adapter", formatter: formatter.NewMarkdownFormatter()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want, err := tt.formatter.Format(ctx, vm)
+			if err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+
+			sf := formatter.Stream(tt.formatter)
+			if native := sf == tt.formatter; native != tt.wantNative {
+				t.Fatalf("Stream() returned formatter itself = %v, want %v", native, tt.wantNative)
+			}
+
+			var buf bytes.Buffer
+			if err := sf.FormatTo(ctx, &buf, vm); err != nil {
+				t.Fatalf("FormatTo() error = %v", err)
+			}
+			if buf.String() != want {
+				t.Fatalf("FormatTo() output differs from Format():\n%s\n---\n%s", buf.String(), want)
+			}
+
+			// отказ записи посреди вывода приходит как ошибка ввода-вывода
+			w := &limitedWriter{limit: len(want) / 2}
+			if err := sf.FormatTo(ctx, w, vm); !appErrors.IsCode(err, appErrors.CodeIO) {
+				t.Fatalf("expected IO error on failed write, got %v", err)
+			}
+		})
+	}
+}
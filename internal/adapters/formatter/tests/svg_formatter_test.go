@@ -0,0 +1,111 @@
+package tests
+
+import (
+	"context"
+	"encoding/xml"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/xeniasokk/field-switcher/internal/adapters/formatter"
+	"github.com/xeniasokk/field-switcher/internal/adapters/presenter"
+	"github.com/xeniasokk/field-switcher/internal/domain/dream"
+	"github.com/xeniasokk/field-switcher/pkg/document"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func TestSVGFormatterGolden(t *testing.T) {
+	ctx := context.Background()
+	child, err := dream.NewDefaultFootballerDream()
+	if err != nil {
+		t.Fatalf("failed to create default footballer dream: %v", err)
+	}
+	f, _ := dream.NewField("Поле разработки", "Команда")
+
+	tests := []struct {
+		name   string
+		golden string
+		role   string
+		stack  []string
+	}{
+		{
+			name:   "default team lead",
+			golden: "svg_card_default.golden.svg",
+			role:   "Тимлид",
+			stack:  []string{"System Design", "Team Leadership", "Agile/Scrum", "Code Review", "CI/CD"},
+		},
+		{
+			name:   "long cyrillic strings wrap",
+			golden: "svg_card_long.golden.svg",
+			role:   "Главный архитектор распределённых высоконагруженных систем реального времени",
+			stack: []string{
+				"Непрерывная интеграция и доставка в нескольких облаках одновременно",
+				"Go", "Kubernetes", "<script>", "Наблюдаемость",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := dream.NewAdult(tt.role, "Desc", f, tt.stack, child.Qualities(), "")
+			if err != nil {
+				t.Fatalf("failed to create adult: %v", err)
+			}
+			vm := presenter.NewConsoleViewModel("field-switcher — трансформация мечты", child, a, "note")
+
+			got, err := formatter.NewSVGFormatter().Format(ctx, vm)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := xml.Unmarshal([]byte(got), new(struct{})); err != nil {
+				t.Fatalf("output is not well-formed XML: %v", err)
+			}
+
+			path := filepath.Join("testdata", tt.golden)
+			if *update {
+				if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+					t.Fatalf("failed to update golden file: %v", err)
+				}
+			}
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("failed to read golden file: %v", err)
+			}
+			if got != string(want) {
+				t.Fatalf("SVG differs from %s; run go test with -update to refresh.\ngot:\n%s", path, got)
+			}
+			if strings.Contains(got, "<script>") {
+				t.Fatalf("expected user strings to be escaped")
+			}
+		})
+	}
+}
+
+func TestSVGFormatterRanksQualitiesByWeight(t *testing.T) {
+	item := func(name string, weight int) document.Item {
+		return document.Item{Name: document.Emphasized(name, document.EmphasisTerm), Weight: weight}
+	}
+	vm := documentViewModel{doc: document.Document{Sections: []document.Section{{
+		Key: string(formatter.MsgChildhood),
+		Blocks: []document.Block{document.List{Label: string(formatter.MsgQualities), Items: []document.Item{
+			item("Скорость", 1), item("Упорство", 9), item("Точность", 0), item("Выносливость", 4),
+		}}},
+	}}}}
+
+	out, err := formatter.NewSVGFormatter(formatter.WithTopQualities(2)).Format(context.Background(), vm)
+	if err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+	first, second := strings.Index(out, ">Упорство<"), strings.Index(out, ">Выносливость<")
+	if first < 0 || second < first {
+		t.Errorf("expected the two strongest qualities in rank order:\n%s", out)
+	}
+	for _, weak := range []string{">Скорость<", ">Точность<"} {
+		if strings.Contains(out, weak) {
+			t.Errorf("weaker quality %s made the card:\n%s", weak, out)
+		}
+	}
+}
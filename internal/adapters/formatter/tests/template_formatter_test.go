@@ -0,0 +1,173 @@
+package tests
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/xeniasokk/field-switcher/internal/adapters/formatter"
+	"github.com/xeniasokk/field-switcher/internal/adapters/presenter"
+	"github.com/xeniasokk/field-switcher/internal/domain/dream"
+	appErrors "github.com/xeniasokk/field-switcher/pkg/errors"
+)
+
+func TestTemplateFormatterFormat(t *testing.T) {
+	ctx := context.Background()
+	child, err := dream.NewDefaultFootballerDream()
+	if err != nil {
+		t.Fatalf("failed to create default footballer dream: %v", err)
+	}
+	f, _ := dream.NewField("Dev", "Team")
+	a, _ := dream.NewAdult("Тимлид", "Капитан команды на новом поле", f, []string{"Go", "SQL"}, child.Qualities(), "comment")
+	vm := presenter.NewConsoleViewModel("title", child, a, "note")
+
+	tests := []struct {
+		name     string
+		source   string
+		opts     []formatter.TemplateOption
+		want     string
+		wantCode appErrors.Code
+	}{
+		{
+			name:   "fields and helpers",
+			source: `{{ upper .adult.role_title }}: {{ join ", " .adult.stack }}`,
+			want:   "ТИМЛИД: Go, SQL",
+		},
+		{
+			name:   "russian plural",
+			source: `{{ plural 1 "мечта" "мечты" "мечт" }} {{ plural 3 "мечта" "мечты" "мечт" }} {{ plural 11 "мечта" "мечты" "мечт" }} {{ plural 21 "мечта" "мечты" "мечт" }}`,
+			want:   "мечта мечты мечт мечта",
+		},
+		{
+			name:   "english plural",
+			source: `{{ plural 1 "dream" "dreams" }} {{ plural 2 "dream" "dreams" }}`,
+			want:   "dream dreams",
+		},
+		{
+			name:   "plural with traits count",
+			source: `{{ $n := len .adult.traits }}{{ $n }} {{ plural $n "качество" "качества" "качеств" }}`,
+			want:   "5 качеств",
+		},
+		{
+			name:   "wrap",
+			source: `{{ wrap 16 .adult.role_description }}`,
+			want:   "Капитан команды\nна новом поле",
+		},
+		{
+			name:   "color disabled keeps plain text",
+			source: `{{ color "persistence" "Упорство" }}`,
+			want:   "Упорство",
+		},
+		{
+			name:   "color enabled",
+			source: `{{ color "red" "Упорство" }}`,
+			opts:   []formatter.TemplateOption{formatter.WithTemplateColor(true)},
+			want:   "\x1b[31mУпорство\x1b[0m",
+		},
+		{
+			name:     "unknown color",
+			source:   `{{ color "ultraviolet" "x" }}`,
+			wantCode: appErrors.CodeValidation,
+		},
+		{
+			name:   "missing field is tolerated by default",
+			source: `[{{ .adult.salary }}]`,
+			want:   "[<no value>]",
+		},
+		{
+			name:     "missing field fails in strict mode",
+			source:   `[{{ .adult.salary }}]`,
+			opts:     []formatter.TemplateOption{formatter.WithStrict()},
+			wantCode: appErrors.CodeValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tf, err := formatter.NewTemplateFormatter(tt.source, tt.opts...)
+			if err != nil {
+				t.Fatalf("NewTemplateFormatter() error = %v", err)
+			}
+			out, err := tf.Format(ctx, vm)
+			if tt.wantCode != "" {
+				if !appErrors.IsCode(err, tt.wantCode) {
+					t.Fatalf("expected %s error, got %v", tt.wantCode, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+			if out != tt.want {
+				t.Fatalf("Format() = %q, want %q", out, tt.want)
+			}
+		})
+	}
+}
+
+func TestTemplateFormatterErrors(t *testing.T) {
+	if _, err := formatter.NewTemplateFormatter(`{{ .title `); !appErrors.IsCode(err, appErrors.CodeValidation) {
+		t.Fatalf("expected validation error for broken template, got %v", err)
+	}
+	if _, err := formatter.NewTemplateFormatter(`{{ shout .title }}`); !appErrors.IsCode(err, appErrors.CodeValidation) {
+		t.Fatalf("expected validation error for unknown function, got %v", err)
+	}
+	if _, err := formatter.LoadTemplateFile(filepath.Join(t.TempDir(), "missing.tmpl")); !appErrors.IsCode(err, appErrors.CodeIO) {
+		t.Fatalf("expected IO error for missing file, got %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "ok.tmpl")
+	if err := os.WriteFile(path, []byte(`{{ .title }}`), 0o600); err != nil {
+		t.Fatalf("write template: %v", err)
+	}
+	tf, err := formatter.LoadTemplateFile(path)
+	if err != nil {
+		t.Fatalf("LoadTemplateFile() error = %v", err)
+	}
+	child, _ := dream.NewDefaultFootballerDream()
+	f, _ := dream.NewField("Dev", "Team")
+	a, _ := dream.NewAdult("Role", "Desc", f, nil, nil, "")
+	out, err := tf.Format(context.Background(), presenter.NewConsoleViewModel("title", child, a, ""))
+	if err != nil || strings.TrimSpace(out) != "title" {
+		t.Fatalf("Format() = %q, %v", out, err)
+	}
+}
+
+// Образец из docs выделяет качества по полю highlighted, а не по имени, и работает в строгом режиме.
+func TestTemplateFormatterSampleHighlights(t *testing.T) {
+	ctx := context.Background()
+	child, err := dream.NewDefaultFootballerDream()
+	if err != nil {
+		t.Fatalf("failed to create default footballer dream: %v", err)
+	}
+	f, _ := dream.NewField("Dev", "Team")
+	a, _ := dream.NewAdult("Тимлид", "Капитан", f, []string{"Go"}, child.Qualities(), "")
+	highlighted, plain := child.Qualities()[1].Name(), dream.QualityPersistence
+	vm := presenter.NewConsoleViewModel("title", child, a, "").WithHighlights(highlighted)
+
+	opts := []formatter.TemplateOption{formatter.WithStrict(), formatter.WithTemplateColor(true)}
+	tf, err := formatter.LoadTemplateFile(filepath.Join("..", "..", "..", "..", "docs", "templates", "summary.tmpl"), opts...)
+	if err != nil {
+		t.Fatalf("LoadTemplateFile() error = %v", err)
+	}
+	out, err := tf.Format(ctx, vm)
+	if err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+
+	for slot, name := range map[string]string{"persistence": highlighted, "quality": plain} {
+		colored, err := formatter.NewTemplateFormatter(`{{ color "`+slot+`" "`+name+`" }}`, opts...)
+		if err != nil {
+			t.Fatalf("NewTemplateFormatter() error = %v", err)
+		}
+		want, err := colored.Format(ctx, vm)
+		if err != nil {
+			t.Fatalf("Format() error = %v", err)
+		}
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %s colored as %s in:\n%q", name, slot, out)
+		}
+	}
+}
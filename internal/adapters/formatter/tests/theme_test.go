@@ -0,0 +1,163 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/xeniasokk/field-switcher/internal/adapters/formatter"
+	"github.com/xeniasokk/field-switcher/internal/adapters/presenter"
+	"github.com/xeniasokk/field-switcher/internal/domain/dream"
+)
+
+func TestParseTheme(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+		check   func(t *testing.T, theme formatter.Theme)
+	}{
+		{
+			name: "overrides slots on top of base theme",
+			data: `{"name": "mine", "base": "light", "slots": {"persistence": {"fg": "#ff8800", "bold": true}}}`,
+			check: func(t *testing.T, theme formatter.Theme) {
+				if theme.Name != "mine" {
+					t.Fatalf("expected name 'mine', got %q", theme.Name)
+				}
+				if theme.Slots[formatter.SlotPersistence].FG != "#ff8800" {
+					t.Fatalf("expected persistence override")
+				}
+				if theme.Slots[formatter.SlotTitle].FG != "blue" {
+					t.Fatalf("expected title inherited from light theme")
+				}
+			},
+		},
+		{
+			name:    "unknown slot",
+			data:    `{"slots": {"nope": {"fg": "red"}}}`,
+			wantErr: true,
+		},
+		{
+			name:    "unknown color",
+			data:    `{"slots": {"title": {"fg": "ultraviolet"}}}`,
+			wantErr: true,
+		},
+		{
+			name:    "unknown base",
+			data:    `{"base": "solarized"}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			theme, err := formatter.ParseTheme([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTheme() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.check != nil {
+				tt.check(t, theme)
+			}
+		})
+	}
+}
+
+func TestTextFormatterThemes(t *testing.T) {
+	ctx := context.Background()
+	child, err := dream.NewDefaultFootballerDream()
+	if err != nil {
+		t.Fatalf("failed to create default footballer dream: %v", err)
+	}
+	f, _ := dream.NewField("Dev", "Team")
+	a, _ := dream.NewAdult("Role", "Desc", f, []string{"Go"}, child.Qualities(), "comment")
+	vm := presenter.NewConsoleViewModel("title", child, a, "note")
+
+	tests := []struct {
+		name         string
+		theme        string
+		color        bool
+		wantContains []string
+		wantMissing  []string
+		// wantNoFgColors: в выводе нет ни одного кода цвета текста (30–37, 90–97)
+		wantNoFgColors bool
+	}{
+		{
+			name:         "dark theme with color",
+			theme:        formatter.ThemeDark,
+			color:        true,
+			wantContains: []string{"\x1b[36;1mtitle"},
+		},
+		{
+			name:         "light theme with color",
+			theme:        formatter.ThemeLight,
+			color:        true,
+			wantContains: []string{"\x1b[34;1mtitle"},
+		},
+		{
+			name:           "monochrome keeps only emphasis",
+			theme:          formatter.ThemeMonochrome,
+			color:          true,
+			wantContains:   []string{"\x1b[1mtitle", "\x1b[3mcomment"},
+			wantNoFgColors: true,
+		},
+		{
+			name:        "color disabled",
+			theme:       formatter.ThemeHighContrast,
+			color:       false,
+			wantMissing: []string{"\x1b["},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			theme, err := formatter.BuiltinTheme(tt.theme)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			text, err := formatter.NewTextFormatter(formatter.WithTheme(theme), formatter.WithColor(tt.color)).Format(ctx, vm)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for _, want := range tt.wantContains {
+				if !strings.Contains(text, want) {
+					t.Fatalf("expected output to contain %q, got %q", want, text)
+				}
+			}
+			for _, missing := range tt.wantMissing {
+				if strings.Contains(text, missing) {
+					t.Fatalf("expected output not to contain %q", missing)
+				}
+			}
+			if tt.wantNoFgColors && fgColorCode.MatchString(text) {
+				t.Fatalf("expected no foreground colors, got %q", text)
+			}
+		})
+	}
+}
+
+var fgColorCode = regexp.MustCompile(`\x1b\[(?:\d+;)*(?:3[0-7]|9[0-7])(?:;\d+)*m`)
+
+func TestColorEnabled(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    formatter.ColorMode
+		noColor string
+		want    bool
+	}{
+		{name: "always", mode: formatter.ColorAlways, noColor: "1", want: true},
+		{name: "never", mode: formatter.ColorNever, want: false},
+		{name: "auto respects NO_COLOR", mode: formatter.ColorAuto, noColor: "1", want: false},
+		{name: "auto disables color for non-TTY writers", mode: formatter.ColorAuto, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("NO_COLOR", tt.noColor)
+			if got := formatter.ColorEnabled(tt.mode, &bytes.Buffer{}); got != tt.want {
+				t.Fatalf("ColorEnabled() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package tests
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/xeniasokk/field-switcher/internal/adapters/formatter"
+	"github.com/xeniasokk/field-switcher/internal/adapters/presenter"
+	"github.com/xeniasokk/field-switcher/internal/domain/dream"
+)
+
+func TestDisplayWidth(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want int
+	}{
+		{name: "ascii", in: "Go", want: 2},
+		{name: "cyrillic", in: "Упорство", want: 8},
+		{name: "ansi codes are ignored", in: "\x1b[1;31mУпорство\x1b[0m", want: 8},
+		{name: "combining mark", in: "Ёмкость", want: 7},
+		{name: "cjk", in: "夢", want: 2},
+		{name: "emoji", in: "⚽ гол", want: 6},
+		{name: "zwj sequence", in: "👨‍💻", want: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatter.DisplayWidth(tt.in); got != tt.want {
+				t.Fatalf("DisplayWidth(%q) = %d, want %d", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTextFormatterWidth(t *testing.T) {
+	ctx := context.Background()
+	child, err := dream.NewDefaultFootballerDream()
+	if err != nil {
+		t.Fatalf("failed to create default footballer dream: %v", err)
+	}
+	f, _ := dream.NewField("Поле разработки", "Команда разработчиков, репозитории, прод-среда")
+	a, _ := dream.NewAdult(
+		"Тимлид",
+		"Капитан команды на новом поле: вместо капитанской повязки — ответственность за команду",
+		f,
+		[]string{"System Design", "Observability"},
+		child.Qualities(),
+		"Ты не отказался от мечты — ты просто сменил поле и стал капитаном команды.",
+	)
+	vm := presenter.NewConsoleViewModel("field-switcher — трансформация мечты", child, a, "note")
+
+	tests := []struct {
+		name      string
+		width     int
+		boxes     bool
+		wantLines []string
+	}{
+		{
+			name:      "no width keeps long lines",
+			wantLines: []string{"Описание: Капитан команды на новом поле: вместо капитанской повязки — ответственность за команду"},
+		},
+		{
+			name:      "wrapped values use hanging indent",
+			width:     50,
+			wantLines: []string{"Описание: Капитан команды на новом поле: вместо", "          капитанской повязки — ответственность за"},
+		},
+		{
+			name:      "boxes",
+			width:     40,
+			boxes:     true,
+			wantLines: []string{"┌─ ДЕТСКАЯ МЕЧТА ──────────────────────┐", "└──────────────────────────────────────┘"},
+		},
+		{
+			name:      "narrow boxes put labels above values",
+			width:     20,
+			boxes:     true,
+			wantLines: []string{"┌─ ДЕТСКАЯ МЕЧТА ──┐", "│ Название:        │", "│   Футболист      │"},
+		},
+		{
+			name:      "very narrow boxes truncate headings",
+			width:     8,
+			boxes:     true,
+			wantLines: []string{"┌─ ДЕ… ┐"},
+		},
+		{
+			name:      "boxes without width fit content",
+			boxes:     true,
+			wantLines: []string{"│ Роль:     Тимлид"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := formatter.NewTextFormatter(
+				formatter.WithColor(false),
+				formatter.WithWidth(tt.width),
+				formatter.WithBoxes(tt.boxes),
+			).Format(ctx, vm)
+			if err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+
+			lines := strings.Split(out, "\n")
+			for _, want := range tt.wantLines {
+				found := false
+				for _, line := range lines {
+					if strings.HasPrefix(line, want) {
+						found = true
+						break
+					}
+				}
+				if !found {
+					t.Fatalf("expected line %q in output:\n%s", want, out)
+				}
+			}
+			if tt.boxes {
+				// строки каждой рамки одной ширины, в том числе вычисленной по содержимому
+				frameWidth := -1
+				for _, line := range lines {
+					if !strings.HasPrefix(line, "┌") && !strings.HasPrefix(line, "│") && !strings.HasPrefix(line, "└") {
+						continue
+					}
+					if strings.HasPrefix(line, "┌") {
+						frameWidth = formatter.DisplayWidth(line)
+					}
+					if w := formatter.DisplayWidth(line); w != frameWidth {
+						t.Fatalf("frame line %q is %d columns wide, expected %d", line, w, frameWidth)
+					}
+				}
+			}
+			if tt.width > 0 {
+				for _, line := range lines {
+					if w := formatter.DisplayWidth(line); w > tt.width {
+						t.Fatalf("line %q is %d columns wide, limit %d", line, w, tt.width)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/xeniasokk/field-switcher/internal/adapters/formatter"
+	"github.com/xeniasokk/field-switcher/internal/adapters/presenter"
+	"github.com/xeniasokk/field-switcher/internal/application/usecase/transform"
+	"github.com/xeniasokk/field-switcher/internal/domain/dream"
+	"github.com/xeniasokk/field-switcher/internal/ports"
+)
+
+func TestYAMLFormatterFormat(t *testing.T) {
+	ctx := context.Background()
+	child, err := dream.NewDefaultFootballerDream()
+	if err != nil {
+		t.Fatalf("failed to create default footballer dream: %v", err)
+	}
+	role, err := dream.RoleTeamLead.Config()
+	if err != nil {
+		t.Fatalf("failed to get role config: %v", err)
+	}
+	f, _ := dream.NewField("Dev", "Team")
+
+	tests := []struct {
+		name         string
+		comment      string
+		stack        []string
+		wantContains []string
+	}{
+		{
+			name:    "long description is folded",
+			comment: "comment",
+			stack:   []string{"Go"},
+			wantContains: []string{
+				"schema_version: \"1\"\n",
+				"  role_description: >-\n    Капитан команды",
+				"  stack:\n  - Go\n",
+				"comment: comment\n",
+			},
+		},
+		{
+			name:    "multi-line comment uses literal block",
+			comment: "первая строка\nвторая строка\n",
+			wantContains: []string{
+				"comment: |\n  первая строка\n  вторая строка\n",
+				"  stack: []\n",
+			},
+		},
+		{
+			name:    "ambiguous scalars are quoted",
+			comment: "yes",
+			stack:   []string{"- dash", "key: value", "42", "C#"},
+			wantContains: []string{
+				"comment: \"yes\"\n",
+				"  - \"- dash\"\n",
+				"  - \"key: value\"\n",
+				"  - \"42\"\n",
+				"  - C#\n",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := dream.NewAdult(role.Title(), role.Description(), f, tt.stack, child.Qualities(), tt.comment)
+			if err != nil {
+				t.Fatalf("failed to create adult: %v", err)
+			}
+			vm := presenter.NewConsoleViewModel("title", child, a, "note")
+
+			text, err := formatter.NewYAMLFormatter().Format(ctx, vm)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for _, want := range tt.wantContains {
+				if !strings.Contains(text, want) {
+					t.Fatalf("expected YAML to contain %q, got:\n%s", want, text)
+				}
+			}
+			for _, line := range strings.Split(text, "\n") {
+				if len([]rune(line)) > 80 {
+					t.Fatalf("line exceeds width: %q", line)
+				}
+			}
+		})
+	}
+}
+
+// TestYAMLFormatterRoundTrip читает вывод настоящим YAML-парсером и сверяет данные с JSON
+// той же модели: блочные скаляры и кавычки не должны менять ни одного значения.
+func TestYAMLFormatterRoundTrip(t *testing.T) {
+	child, err := dream.NewDefaultFootballerDream()
+	if err != nil {
+		t.Fatalf("failed to create default footballer dream: %v", err)
+	}
+	f, _ := dream.NewField("Dev: платформа", "#команда")
+
+	tests := []struct {
+		name        string
+		width       int
+		description string
+		comment     string
+		stack       []string
+	}{
+		{
+			name:        "folded and literal blocks",
+			width:       20,
+			description: strings.Repeat("длинное описание роли ", 8),
+			comment:     "первая строка\n  с отступом\n\nпосле пустой\n",
+		},
+		{
+			name:        "ambiguous scalars",
+			width:       80,
+			description: "yes",
+			comment:     "  ведущие и хвостовые пробелы  ",
+			stack:       []string{"- dash", "key: value", "42", "true", "null", "C#", "'quoted'", `"double"`, ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := dream.NewAdult("Тимлид", tt.description, f, tt.stack, child.Qualities(), tt.comment)
+			if err != nil {
+				t.Fatalf("failed to create adult: %v", err)
+			}
+			vm := presenter.NewConsoleViewModel("title: \"x\"", child, a, "note").
+				WithSources([]dream.ChildhoodDream{child, child})
+
+			assertYAMLMatchesJSON(t, formatter.NewYAMLFormatter(formatter.WithYAMLLineWidth(tt.width)), vm)
+		})
+	}
+}
+
+// Режимы опускают разделы; YAML должен опускать те же ключи, что и JSON.
+func TestYAMLFormatterModesMatchJSON(t *testing.T) {
+	ctx := context.Background()
+	child, err := dream.NewDefaultFootballerDream()
+	if err != nil {
+		t.Fatalf("failed to create default footballer dream: %v", err)
+	}
+	f, _ := dream.NewField("Dev", "Team")
+	a, _ := dream.NewAdult("Тимлид", "", f, []string{"Go"}, child.Qualities(), "")
+
+	for _, mode := range []presenter.Mode{presenter.ModeCompact, presenter.ModeDetailed, presenter.ModeExecutive} {
+		t.Run(string(mode), func(t *testing.T) {
+			p, err := presenter.NewPresenter(mode)
+			if err != nil {
+				t.Fatalf("NewPresenter() error = %v", err)
+			}
+			vm, err := p.Present(ctx, transform.NewOutput(child, a))
+			if err != nil {
+				t.Fatalf("Present() error = %v", err)
+			}
+			assertYAMLMatchesJSON(t, formatter.NewYAMLFormatter(), vm)
+		})
+	}
+}
+
+func assertYAMLMatchesJSON(t *testing.T, f *formatter.YAMLFormatter, vm ports.ViewModel) {
+	t.Helper()
+	ctx := context.Background()
+	text, err := f.Format(ctx, vm)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var fromYAML any
+	if err := yaml.Unmarshal([]byte(text), &fromYAML); err != nil {
+		t.Fatalf("YAML does not parse: %v\n%s", err, text)
+	}
+	// числа и ключи приводятся к виду encoding/json
+	normalized, err := json.Marshal(fromYAML)
+	if err != nil {
+		t.Fatalf("failed to re-encode YAML data: %v", err)
+	}
+
+	raw, err := formatter.NewJSONFormatter().Format(ctx, vm)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var want, got any
+	if err := json.Unmarshal([]byte(raw), &want); err != nil {
+		t.Fatalf("failed to decode JSON: %v", err)
+	}
+	if err := json.Unmarshal(normalized, &got); err != nil {
+		t.Fatalf("failed to decode normalized YAML: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("YAML round trip differs from JSON:\ngot  %s\nwant %s\nyaml:\n%s", normalized, raw, text)
+	}
+}
@@ -2,37 +2,129 @@ package formatter
 
 import (
 	"context"
-	"fmt"
+	"io"
 	"strings"
 
 	"github.com/fatih/color"
 
-	"github.com/xeniasokk/field-switcher/internal/domain/dream"
 	"github.com/xeniasokk/field-switcher/internal/ports"
+	"github.com/xeniasokk/field-switcher/pkg/document"
 )
 
-var _ ports.FormatterPort = (*TextFormatter)(nil)
+var _ ports.StreamFormatterPort = (*TextFormatter)(nil)
 
-type TextFormatter struct{}
+type TextFormatter struct {
+	theme Theme
+	// nil — решение о цвете остаётся за fatih/color (NO_COLOR и TTY для stdout)
+	color *bool
+	// 0 — строки не переносятся
+	width   int
+	boxes   bool
+	layout  TextLayout
+	catalog Catalog
+}
+
+type TextOption func(*TextFormatter)
+
+func WithTheme(theme Theme) TextOption {
+	return func(f *TextFormatter) {
+		f.theme = theme
+	}
+}
+
+func WithColor(enabled bool) TextOption {
+	return func(f *TextFormatter) {
+		f.color = &enabled
+	}
+}
 
-func NewTextFormatter() *TextFormatter {
-	return &TextFormatter{}
+func WithWidth(width int) TextOption {
+	return func(f *TextFormatter) {
+		f.width = max(width, 0)
+	}
+}
+
+func WithBoxes(enabled bool) TextOption {
+	return func(f *TextFormatter) {
+		f.boxes = enabled
+	}
+}
+
+// На узком терминале side-by-side возвращается к обычной раскладке.
+func WithLayout(layout TextLayout) TextOption {
+	return func(f *TextFormatter) {
+		f.layout = layout
+	}
+}
+
+func WithCatalog(catalog Catalog) TextOption {
+	return func(f *TextFormatter) {
+		f.catalog = catalog
+	}
+}
+
+func NewTextFormatter(opts ...TextOption) *TextFormatter {
+	f := &TextFormatter{
+		theme:  builtinThemes[ThemeDark],
+		layout: LayoutStacked,
+	}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
 }
 
 func (f *TextFormatter) Format(ctx context.Context, vm ports.ViewModel) (string, error) {
+	var b strings.Builder
+	if err := f.FormatTo(ctx, &b, vm); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+func (f *TextFormatter) FormatTo(ctx context.Context, w io.Writer, vm ports.ViewModel) error {
 	_ = ctx
 
 	colors := f.InitColors()
+	sw := &streamWriter{w: w}
 	var b strings.Builder
+	flush := func() {
+		_, _ = sw.WriteString(b.String())
+		b.Reset()
+	}
 
-	f.WriteTitle(&b, vm, colors)
-	f.WriteChildhoodSection(&b, vm, colors)
-	b.WriteString("\n")
-	f.WriteAdultSection(&b, vm, colors)
-	f.WriteNote(&b, vm, colors)
-	f.WriteComment(&b, vm, colors)
+	doc := f.catalog.Localize(vm.Document())
+	f.WriteTitle(&b, doc, colors)
+	flush()
 
-	return b.String(), nil
+	// колонки нужны только если в документе есть оба раздела
+	keys := make(map[string]bool, len(doc.Sections))
+	for _, section := range doc.Sections {
+		keys[section.Key] = true
+	}
+	pairable := keys[string(MsgChildhood)] && keys[string(MsgAdult)]
+	written, columns := 0, false
+	for _, section := range doc.Sections {
+		paired := pairable && (section.Key == string(MsgChildhood) || section.Key == string(MsgAdult))
+		if paired && columns {
+			continue
+		}
+		if written > 0 {
+			b.WriteString("\n")
+		}
+		written++
+		if paired && f.layout == LayoutSideBySide && f.WriteSideBySide(&b, doc, colors) {
+			columns = true
+		} else {
+			f.WriteSection(&b, section, colors)
+		}
+		flush()
+	}
+	f.WriteNotes(&b, doc, colors)
+	f.WriteQuotes(&b, doc, colors)
+	flush()
+
+	return sw.ioError()
 }
 
 type colorScheme struct {
@@ -51,138 +143,307 @@ type colorScheme struct {
 }
 
 func (f *TextFormatter) InitColors() colorScheme {
-	return colorScheme{
-		title:            color.New(color.FgCyan, color.Bold),
-		childhoodSection: color.New(color.FgYellow, color.Bold),
-		adultSection:     color.New(color.FgGreen, color.Bold),
-		label:            color.New(color.FgWhite, color.Bold),
-		value:            color.New(color.FgHiWhite),
-		quality:          color.New(color.FgHiCyan),
-		persistence:      color.New(color.FgHiRed, color.Bold),
-		stack:            color.New(color.FgHiMagenta),
-		note:             color.New(color.FgHiBlue),
-		comment:          color.New(color.FgHiYellow, color.Italic),
-		bullet:           color.New(color.FgHiGreen),
-		secondary:        color.New(color.FgHiBlack),
-	}
-}
-
-func (f *TextFormatter) WriteTitle(b *strings.Builder, cvm ports.ViewModel, colors colorScheme) {
-	if cvm.Title() != "" {
-		b.WriteString(colors.title.Sprint(cvm.Title()))
-		b.WriteString("\n\n")
-	}
-}
-
-func (f *TextFormatter) WriteChildhoodSection(b *strings.Builder, cvm ports.ViewModel, colors colorScheme) {
-	b.WriteString(colors.childhoodSection.Sprint("ДЕТСКАЯ МЕЧТА\n"))
-	b.WriteString(colors.childhoodSection.Sprint("-----------------\n"))
-	childhood := cvm.Childhood()
-	_, _ = fmt.Fprintf(b, "%s %s\n",
-		colors.label.Sprint("Название:"),
-		colors.value.Sprint(childhood.DisplayName()),
-	)
-	_, _ = fmt.Fprintf(b, "%s %s\n",
-		colors.label.Sprint("Роль:"),
-		colors.value.Sprint(childhood.DesiredRole()),
-	)
-	_, _ = fmt.Fprintf(b, "%s %s %s\n",
-		colors.label.Sprint("Поле:"),
-		colors.value.Sprint(childhood.Field().Name()),
-		colors.secondary.Sprint("("+childhood.Field().Environment()+")"),
-	)
-
-	qualities := childhood.Qualities()
-	if len(qualities) > 0 {
-		b.WriteString(colors.label.Sprint("Качества:\n"))
-		for _, q := range qualities {
-			qualityNameColor := colors.quality
-			if q.Name() == dream.QualityPersistence {
-				qualityNameColor = colors.persistence
-			}
-			_, _ = fmt.Fprintf(b, "  %s %s %s %s\n",
-				colors.bullet.Sprint("•"),
-				qualityNameColor.Sprint(q.Name()),
-				colors.secondary.Sprint("—"),
-				colors.value.Sprint(q.Description()),
-			)
-		}
-	}
-}
-
-func (f *TextFormatter) WriteAdultSection(b *strings.Builder, cvm ports.ViewModel, colors colorScheme) {
-	b.WriteString(colors.adultSection.Sprint("ВЗРОСЛАЯ РОЛЬ\n"))
-	b.WriteString(colors.adultSection.Sprint("--------------\n"))
-	adult := cvm.Adult()
-	_, _ = fmt.Fprintf(b, "%s %s\n",
-		colors.label.Sprint("Роль:"),
-		colors.value.Sprint(adult.RoleTitle()),
-	)
-	if desc := adult.RoleDescription(); desc != "" {
-		_, _ = fmt.Fprintf(b, "%s %s\n",
-			colors.label.Sprint("Описание:"),
-			colors.value.Sprint(desc),
-		)
-	}
-	_, _ = fmt.Fprintf(b, "%s %s %s\n",
-		colors.label.Sprint("Поле:"),
-		colors.value.Sprint(adult.Field().Name()),
-		colors.secondary.Sprint("("+adult.Field().Environment()+")"),
-	)
-
-	stack := adult.Stack()
-	if len(stack) > 0 {
-		b.WriteString(colors.label.Sprint("Стек:\n"))
-		for _, s := range stack {
-			_, _ = fmt.Fprintf(b, "  %s %s\n",
-				colors.bullet.Sprint("•"),
-				colors.stack.Sprint(s),
-			)
-		}
-	}
-
-	traits := adult.Traits()
-	if len(traits) > 0 {
-		b.WriteString(colors.label.Sprint("Сохранённые качества:\n"))
-		for _, q := range traits {
-			traitNameColor := colors.quality
-			if q.Name() == dream.QualityPersistence {
-				traitNameColor = colors.persistence
+	scheme := colorScheme{
+		title:            f.theme.color(SlotTitle),
+		childhoodSection: f.theme.color(SlotChildhoodSection),
+		adultSection:     f.theme.color(SlotAdultSection),
+		label:            f.theme.color(SlotLabel),
+		value:            f.theme.color(SlotValue),
+		quality:          f.theme.color(SlotQuality),
+		persistence:      f.theme.color(SlotPersistence),
+		stack:            f.theme.color(SlotStack),
+		note:             f.theme.color(SlotNote),
+		comment:          f.theme.color(SlotComment),
+		bullet:           f.theme.color(SlotBullet),
+		secondary:        f.theme.color(SlotSecondary),
+	}
+	if f.color != nil {
+		for _, c := range scheme.all() {
+			if *f.color {
+				c.EnableColor()
+			} else {
+				c.DisableColor()
 			}
-			_, _ = fmt.Fprintf(b, "  %s %s %s %s\n",
-				colors.bullet.Sprint("•"),
-				traitNameColor.Sprint(q.Name()),
-				colors.secondary.Sprint("—"),
-				colors.value.Sprint(q.Description()),
-			)
 		}
 	}
+	return scheme
 }
 
-func (f *TextFormatter) WriteNote(b *strings.Builder, cvm ports.ViewModel, colors colorScheme) {
-	if note := cvm.Note(); note != "" {
+func (s colorScheme) all() []*color.Color {
+	return []*color.Color{
+		s.title, s.childhoodSection, s.adultSection, s.label, s.value, s.quality,
+		s.persistence, s.stack, s.note, s.comment, s.bullet, s.secondary,
+	}
+}
+
+func (f *TextFormatter) WriteTitle(b *strings.Builder, doc document.Document, colors colorScheme) {
+	title := doc.Title.String()
+	if title == "" {
+		return
+	}
+	f.writeLines(b, wrapSpans([]span{{title, colors.title}}, f.width))
+	if len(doc.Sections) > 0 || len(doc.Notes) > 0 || len(doc.Quotes) > 0 {
 		b.WriteString("\n")
-		if strings.Contains(note, dream.QualityPersistence) {
-			parts := strings.Split(note, "|")
-			if len(parts) == 2 {
-				b.WriteString(colors.note.Sprint(parts[0]))
-				b.WriteString(" | ")
-				b.WriteString(colors.persistence.Sprint(parts[1]))
-			} else {
-				b.WriteString(colors.note.Sprint(note))
+	}
+}
+
+func (f *TextFormatter) WriteSection(b *strings.Builder, section document.Section, colors colorScheme) {
+	headingColor := colors.childhoodSection
+	if section.Key == string(MsgAdult) {
+		headingColor = colors.adultSection
+	}
+	f.section(b, f.catalog.Heading(MessageKey(section.Key)), headingColor, func(w *strings.Builder, width int) {
+		for _, block := range section.Blocks {
+			switch block := block.(type) {
+			case document.Fields:
+				fields := make([]labeled, 0, len(block))
+				for _, field := range block {
+					fields = append(fields, labeled{
+						f.catalog.Label(MessageKey(field.Label)),
+						textSpans(field.Value, colors.value, colors),
+					})
+				}
+				f.writeFields(w, width, colors, fields)
+			case document.List:
+				if len(block.Items) == 0 {
+					continue
+				}
+				if block.Label != "" {
+					f.writeLines(w, wrapSpans([]span{{f.catalog.Label(MessageKey(block.Label)), colors.label}}, width))
+				}
+				f.writeList(w, listItems(block, colors), width, colors)
 			}
-		} else {
-			b.WriteString(colors.note.Sprint(note))
 		}
+	})
+}
+
+func (f *TextFormatter) WriteNotes(b *strings.Builder, doc document.Document, colors colorScheme) {
+	if len(doc.Notes) == 0 {
+		return
+	}
+	b.WriteString("\n")
+	var spans []span
+	for i, note := range doc.Notes {
+		if i > 0 {
+			spans = append(spans, span{"|", nil})
+		}
+		spans = append(spans, textSpans(note, colors.note, colors)...)
+	}
+	f.writeLines(b, wrapSpans(spans, f.width))
+}
+
+func (f *TextFormatter) WriteQuotes(b *strings.Builder, doc document.Document, colors colorScheme) {
+	for _, quote := range doc.Quotes {
 		b.WriteString("\n")
+		if quote.Label != "" {
+			f.writeLines(b, wrapSpans([]span{{f.catalog.Label(MessageKey(quote.Label)), colors.label}}, f.width))
+		}
+		for _, paragraph := range strings.Split(quote.Text.String(), "\n") {
+			f.writeLines(b, wrapSpans([]span{{paragraph, colors.comment}}, f.width))
+		}
 	}
 }
 
-func (f *TextFormatter) WriteComment(b *strings.Builder, cvm ports.ViewModel, colors colorScheme) {
-	if comment := cvm.Adult().Comment(); comment != "" {
+func (f *TextFormatter) section(
+	b *strings.Builder,
+	heading string,
+	headingColor *color.Color,
+	body func(w *strings.Builder, width int),
+) {
+	if !f.boxes {
+		b.WriteString(headingColor.Sprint(heading))
 		b.WriteString("\n")
-		b.WriteString(colors.label.Sprint("Комментарий:\n"))
-		b.WriteString(colors.comment.Sprint(comment))
+		b.WriteString(headingColor.Sprint(strings.Repeat("-", DisplayWidth(heading))))
+		b.WriteString("\n")
+		body(b, f.width)
+		return
+	}
+
+	// рамка с полями занимает 4 колонки
+	inner := 0
+	if f.width > 0 {
+		inner = max(f.width-4, 2)
+		heading = truncateWidth(heading, inner-1)
+	}
+	var content strings.Builder
+	body(&content, inner)
+	lines := strings.Split(strings.TrimSuffix(content.String(), "\n"), "\n")
+	if inner == 0 {
+		inner = DisplayWidth(heading) + 2
+		for _, line := range lines {
+			inner = max(inner, DisplayWidth(line))
+		}
+	}
+
+	top := "┌─ " + heading + " " + strings.Repeat("─", inner-DisplayWidth(heading)-1) + "┐"
+	b.WriteString(headingColor.Sprint(top))
+	b.WriteString("\n")
+	for _, line := range lines {
+		b.WriteString(headingColor.Sprint("│"))
+		b.WriteString(" ")
+		b.WriteString(padRight(clipWidth(line, inner), inner))
+		b.WriteString(" ")
+		b.WriteString(headingColor.Sprint("│"))
+		b.WriteString("\n")
+	}
+	b.WriteString(headingColor.Sprint("└" + strings.Repeat("─", inner+2) + "┘"))
+	b.WriteString("\n")
+}
+
+const minFieldValueWidth = 10
+
+type labeled struct {
+	label string
+	value []span
+}
+
+func (f *TextFormatter) writeFields(b *strings.Builder, width int, colors colorScheme, fields []labeled) {
+	labelWidth := 0
+	for _, field := range fields {
+		labelWidth = max(labelWidth, DisplayWidth(field.label))
+	}
+	valueWidth := 0
+	if width > 0 {
+		valueWidth = width - labelWidth - 1
+	}
+	// на узком экране значение идёт под подписью с отступом
+	if width > 0 && valueWidth < minFieldValueWidth {
+		for _, field := range fields {
+			f.writeLines(b, wrapSpans([]span{{field.label, colors.label}}, width))
+			indent := min(2, width-1)
+			for _, line := range wrapSpans(field.value, width-indent) {
+				b.WriteString(strings.Repeat(" ", indent))
+				b.WriteString(line)
+				b.WriteString("\n")
+			}
+		}
+		return
+	}
+	indent := strings.Repeat(" ", labelWidth+1)
+	for _, field := range fields {
+		for i, line := range wrapSpans(field.value, valueWidth) {
+			if i == 0 {
+				b.WriteString(padRight(colors.label.Sprint(field.label), labelWidth))
+			} else {
+				b.WriteString(indent[1:])
+			}
+			b.WriteString(" ")
+			b.WriteString(line)
+			b.WriteString("\n")
+		}
+	}
+}
+
+type listItem struct {
+	name      string
+	nameColor *color.Color
+	rest      []span
+}
+
+// Слишком длинные для выравнивания названия не выравниваются.
+func (f *TextFormatter) writeList(b *strings.Builder, items []listItem, width int, colors colorScheme) {
+	const prefix = "  • "
+	nameWidth := 0
+	for _, item := range items {
+		if len(item.rest) > 0 {
+			nameWidth = max(nameWidth, DisplayWidth(item.name))
+		}
+	}
+	if width > 0 && (nameWidth > (width-len([]rune(prefix)))/3 ||
+		width-len([]rune(prefix))-nameWidth-3 < minFieldValueWidth) {
+		nameWidth = 0
+	}
+
+	for _, item := range items {
+		var spans []span
+		hang := len([]rune(prefix))
+		if len(item.rest) > 0 && nameWidth > 0 {
+			name := padRight(item.nameColor.Sprint(item.name), nameWidth)
+			hang += nameWidth + 3
+			avail := 0
+			if width > 0 {
+				avail = width - hang
+			}
+			lines := wrapSpans(item.rest, avail)
+			for i, line := range lines {
+				if i == 0 {
+					b.WriteString("  " + colors.bullet.Sprint("•") + " " + name + " " + colors.secondary.Sprint("—") + " ")
+				} else {
+					b.WriteString(strings.Repeat(" ", hang))
+				}
+				b.WriteString(line)
+				b.WriteString("\n")
+			}
+			continue
+		}
+
+		spans = append(spans, span{item.name, item.nameColor})
+		if len(item.rest) > 0 {
+			spans = append(spans, span{"—", colors.secondary})
+			spans = append(spans, item.rest...)
+		}
+		avail := 0
+		if width > 0 {
+			avail = max(width-hang, 1)
+		}
+		for i, line := range wrapSpans(spans, avail) {
+			if i == 0 {
+				b.WriteString("  " + colors.bullet.Sprint("•") + " ")
+			} else {
+				b.WriteString(strings.Repeat(" ", hang))
+			}
+			b.WriteString(line)
+			b.WriteString("\n")
+		}
+	}
+}
+
+func (f *TextFormatter) writeLines(b *strings.Builder, lines []string) {
+	for _, line := range lines {
+		b.WriteString(line)
 		b.WriteString("\n")
 	}
 }
+
+func (s colorScheme) emphasis(e document.Emphasis, base *color.Color) *color.Color {
+	switch e {
+	case document.EmphasisTerm:
+		return s.quality
+	case document.EmphasisHighlight:
+		return s.persistence
+	case document.EmphasisCode:
+		return s.stack
+	case document.EmphasisMuted:
+		return s.secondary
+	default:
+		return base
+	}
+}
+
+func textSpans(t document.Text, base *color.Color, colors colorScheme) []span {
+	spans := make([]span, 0, len(t))
+	for _, s := range t {
+		text := s.Text
+		if s.Label != "" {
+			text = "(" + text + ")"
+		}
+		spans = append(spans, span{text, colors.emphasis(s.Emphasis, base)})
+	}
+	return spans
+}
+
+func listItems(list document.List, colors colorScheme) []listItem {
+	items := make([]listItem, 0, len(list.Items))
+	for _, item := range list.Items {
+		rest := textSpans(item.Detail, colors.value, colors)
+		if len(item.Refs) > 0 {
+			rest = append(rest, span{"[" + strings.Join(item.Refs, ", ") + "]", colors.secondary})
+		}
+		items = append(items, listItem{
+			name:      item.Name.String(),
+			nameColor: colors.emphasis(item.Name.Emphasis(), colors.value),
+			rest:      rest,
+		})
+	}
+	return items
+}
@@ -0,0 +1,298 @@
+package formatter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/mattn/go-isatty"
+
+	appErrors "github.com/xeniasokk/field-switcher/pkg/errors"
+)
+
+type Slot string
+
+const (
+	SlotTitle            Slot = "title"
+	SlotChildhoodSection Slot = "childhood_section"
+	SlotAdultSection     Slot = "adult_section"
+	SlotLabel            Slot = "label"
+	SlotValue            Slot = "value"
+	SlotQuality          Slot = "quality"
+	SlotPersistence      Slot = "persistence"
+	SlotStack            Slot = "stack"
+	SlotNote             Slot = "note"
+	SlotComment          Slot = "comment"
+	SlotBullet           Slot = "bullet"
+	SlotSecondary        Slot = "secondary"
+)
+
+type Style struct {
+	FG        string `json:"fg,omitempty"`
+	BG        string `json:"bg,omitempty"`
+	Bold      bool   `json:"bold,omitempty"`
+	Italic    bool   `json:"italic,omitempty"`
+	Underline bool   `json:"underline,omitempty"`
+}
+
+type Theme struct {
+	Name  string         `json:"name"`
+	Slots map[Slot]Style `json:"slots"`
+}
+
+const (
+	ThemeDark         = "dark"
+	ThemeLight        = "light"
+	ThemeHighContrast = "high-contrast"
+	ThemeMonochrome   = "monochrome"
+)
+
+var builtinThemes = map[string]Theme{
+	ThemeDark: {
+		Name: ThemeDark,
+		Slots: map[Slot]Style{
+			SlotTitle:            {FG: "cyan", Bold: true},
+			SlotChildhoodSection: {FG: "yellow", Bold: true},
+			SlotAdultSection:     {FG: "green", Bold: true},
+			SlotLabel:            {FG: "white", Bold: true},
+			SlotValue:            {FG: "hi-white"},
+			SlotQuality:          {FG: "hi-cyan"},
+			SlotPersistence:      {FG: "hi-red", Bold: true},
+			SlotStack:            {FG: "hi-magenta"},
+			SlotNote:             {FG: "hi-blue"},
+			SlotComment:          {FG: "hi-yellow", Italic: true},
+			SlotBullet:           {FG: "hi-green"},
+			SlotSecondary:        {FG: "hi-black"},
+		},
+	},
+	ThemeLight: {
+		Name: ThemeLight,
+		Slots: map[Slot]Style{
+			SlotTitle:            {FG: "blue", Bold: true},
+			SlotChildhoodSection: {FG: "magenta", Bold: true},
+			SlotAdultSection:     {FG: "green", Bold: true},
+			SlotLabel:            {FG: "black", Bold: true},
+			SlotValue:            {FG: "black"},
+			SlotQuality:          {FG: "blue"},
+			SlotPersistence:      {FG: "red", Bold: true},
+			SlotStack:            {FG: "magenta"},
+			SlotNote:             {FG: "blue"},
+			SlotComment:          {FG: "black", Italic: true},
+			SlotBullet:           {FG: "green"},
+			SlotSecondary:        {FG: "black"},
+		},
+	},
+	ThemeHighContrast: {
+		Name: ThemeHighContrast,
+		Slots: map[Slot]Style{
+			SlotTitle:            {FG: "hi-white", BG: "blue", Bold: true},
+			SlotChildhoodSection: {FG: "hi-yellow", Bold: true, Underline: true},
+			SlotAdultSection:     {FG: "hi-green", Bold: true, Underline: true},
+			SlotLabel:            {FG: "hi-white", Bold: true},
+			SlotValue:            {FG: "hi-white"},
+			SlotQuality:          {FG: "hi-cyan", Bold: true},
+			SlotPersistence:      {FG: "hi-white", BG: "red", Bold: true},
+			SlotStack:            {FG: "hi-yellow"},
+			SlotNote:             {FG: "hi-cyan"},
+			SlotComment:          {FG: "hi-white", Bold: true},
+			SlotBullet:           {FG: "hi-white", Bold: true},
+			SlotSecondary:        {FG: "hi-white"},
+		},
+	},
+	ThemeMonochrome: {
+		Name: ThemeMonochrome,
+		Slots: map[Slot]Style{
+			SlotTitle:            {Bold: true},
+			SlotChildhoodSection: {Bold: true},
+			SlotAdultSection:     {Bold: true},
+			SlotLabel:            {Bold: true},
+			SlotPersistence:      {Bold: true, Underline: true},
+			SlotComment:          {Italic: true},
+		},
+	},
+}
+
+func ThemeNames() []string {
+	names := make([]string, 0, len(builtinThemes))
+	for name := range builtinThemes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func BuiltinTheme(name string) (Theme, error) {
+	t, ok := builtinThemes[name]
+	if !ok {
+		return Theme{}, appErrors.NewValidationError(
+			fmt.Sprintf("unknown theme %q, available: %s", name, strings.Join(ThemeNames(), ", ")),
+		)
+	}
+	return t.clone(), nil
+}
+
+type themeFile struct {
+	Name  string         `json:"name"`
+	Base  string         `json:"base"`
+	Slots map[Slot]Style `json:"slots"`
+}
+
+// ParseTheme читает тему из JSON. Слоты, не указанные в файле, берутся из базовой темы (по умолчанию dark).
+func ParseTheme(data []byte) (Theme, error) {
+	var tf themeFile
+	if err := json.Unmarshal(data, &tf); err != nil {
+		return Theme{}, appErrors.Wrap(err, appErrors.CodeValidation, "parse theme file")
+	}
+	if tf.Base == "" {
+		tf.Base = ThemeDark
+	}
+	theme, err := BuiltinTheme(tf.Base)
+	if err != nil {
+		return Theme{}, appErrors.Wrap(err, appErrors.CodeValidation, "resolve base theme")
+	}
+	for slot, style := range tf.Slots {
+		if _, ok := builtinThemes[ThemeDark].Slots[slot]; !ok {
+			return Theme{}, appErrors.NewValidationError(fmt.Sprintf("unknown theme slot %q", slot))
+		}
+		if _, err := style.attributes(); err != nil {
+			return Theme{}, appErrors.Wrap(err, appErrors.CodeValidation, fmt.Sprintf("invalid style for slot %q", slot))
+		}
+		theme.Slots[slot] = style
+	}
+	if tf.Name != "" {
+		theme.Name = tf.Name
+	}
+	return theme, nil
+}
+
+func LoadThemeFile(path string) (Theme, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Theme{}, appErrors.Wrap(err, appErrors.CodeIO, fmt.Sprintf("read theme file %s", path))
+	}
+	return ParseTheme(data)
+}
+
+func (t Theme) clone() Theme {
+	slots := make(map[Slot]Style, len(t.Slots))
+	for k, v := range t.Slots {
+		slots[k] = v
+	}
+	return Theme{Name: t.Name, Slots: slots}
+}
+
+func (t Theme) color(slot Slot) *color.Color {
+	attrs, err := t.Slots[slot].attributes()
+	if err != nil {
+		return color.New()
+	}
+	return color.New(attrs...)
+}
+
+var namedColors = map[string]color.Attribute{
+	"black":      color.FgBlack,
+	"red":        color.FgRed,
+	"green":      color.FgGreen,
+	"yellow":     color.FgYellow,
+	"blue":       color.FgBlue,
+	"magenta":    color.FgMagenta,
+	"cyan":       color.FgCyan,
+	"white":      color.FgWhite,
+	"hi-black":   color.FgHiBlack,
+	"hi-red":     color.FgHiRed,
+	"hi-green":   color.FgHiGreen,
+	"hi-yellow":  color.FgHiYellow,
+	"hi-blue":    color.FgHiBlue,
+	"hi-magenta": color.FgHiMagenta,
+	"hi-cyan":    color.FgHiCyan,
+	"hi-white":   color.FgHiWhite,
+}
+
+// Разница между кодами фона и текста в ANSI (40 - 30, 100 - 90)
+const bgOffset = color.BgBlack - color.FgBlack
+
+func (s Style) attributes() ([]color.Attribute, error) {
+	var attrs []color.Attribute
+	if s.FG != "" {
+		a, err := colorAttributes(s.FG, false)
+		if err != nil {
+			return nil, err
+		}
+		attrs = append(attrs, a...)
+	}
+	if s.BG != "" {
+		a, err := colorAttributes(s.BG, true)
+		if err != nil {
+			return nil, err
+		}
+		attrs = append(attrs, a...)
+	}
+	if s.Bold {
+		attrs = append(attrs, color.Bold)
+	}
+	if s.Italic {
+		attrs = append(attrs, color.Italic)
+	}
+	if s.Underline {
+		attrs = append(attrs, color.Underline)
+	}
+	return attrs, nil
+}
+
+func colorAttributes(name string, background bool) ([]color.Attribute, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if a, ok := namedColors[name]; ok {
+		if background {
+			a += bgOffset
+		}
+		return []color.Attribute{a}, nil
+	}
+	if strings.HasPrefix(name, "#") && len(name) == 7 {
+		v, err := strconv.ParseUint(name[1:], 16, 32)
+		if err == nil {
+			mode := color.Attribute(38)
+			if background {
+				mode = 48
+			}
+			return []color.Attribute{
+				mode, 2,
+				color.Attribute(v >> 16 & 0xff), color.Attribute(v >> 8 & 0xff), color.Attribute(v & 0xff),
+			}, nil
+		}
+	}
+	return nil, appErrors.NewValidationError(fmt.Sprintf("unknown color %q", name))
+}
+
+type ColorMode string
+
+const (
+	ColorAuto   ColorMode = "auto"
+	ColorAlways ColorMode = "always"
+	ColorNever  ColorMode = "never"
+)
+
+// ColorEnabled в режиме auto отключает цвет при NO_COLOR, TERM=dumb и выводе не в терминал.
+func ColorEnabled(mode ColorMode, out io.Writer) bool {
+	switch mode {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	}
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	if os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	f, ok := out.(*os.File)
+	if !ok {
+		return false
+	}
+	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+}
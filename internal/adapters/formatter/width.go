@@ -0,0 +1,192 @@
+package formatter
+
+import (
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/fatih/color"
+	"golang.org/x/term"
+)
+
+var ansiSequence = regexp.MustCompile(`\x1b\[[0-9;]*[A-Za-z]`)
+
+func StripANSI(s string) string {
+	return ansiSequence.ReplaceAllString(s, "")
+}
+
+// DisplayWidth не считает ANSI-коды и комбинируемые символы; CJK и эмодзи занимают две колонки.
+func DisplayWidth(s string) int {
+	width := 0
+	joined := false
+	for _, r := range StripANSI(s) {
+		switch {
+		case r == '\u200d':
+			joined = true
+			continue
+		case joined:
+			// вторая половина ZWJ-последовательности рисуется поверх первой
+			joined = false
+			continue
+		}
+		width += RuneWidth(r)
+	}
+	return width
+}
+
+func RuneWidth(r rune) int {
+	switch {
+	case r == 0, r < 0x20, r >= 0x7f && r < 0xa0:
+		return 0
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf),
+		r >= 0xfe00 && r <= 0xfe0f,
+		r >= 0x1f3fb && r <= 0x1f3ff:
+		return 0
+	case isWide(r),
+		r >= 0x1100 && r <= 0x115f,
+		r >= 0x2e80 && r <= 0xa4cf,
+		r >= 0xac00 && r <= 0xd7a3,
+		r >= 0xf900 && r <= 0xfaff,
+		r >= 0xfe30 && r <= 0xfe4f,
+		r >= 0x1f000 && r <= 0x1faff,
+		r >= 0x20000 && r <= 0x3fffd:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// DetectWidth: ширина терминала или COLUMNS; 0 — неизвестна, строки не переносятся.
+func DetectWidth(out io.Writer) int {
+	if f, ok := out.(*os.File); ok {
+		if w, _, err := term.GetSize(int(f.Fd())); err == nil && w > 0 {
+			return w
+		}
+	}
+	if w, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && w > 0 {
+		return w
+	}
+	return 0
+}
+
+func IsTerminal(out io.Writer) bool {
+	f, ok := out.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
+
+type span struct {
+	text  string
+	color *color.Color
+}
+
+// wrapSpans: width 0 — без переноса; слова длиннее строки режутся по символам.
+func wrapSpans(spans []span, width int) []string {
+	type word struct {
+		text  string
+		color *color.Color
+	}
+	var words []word
+	for _, s := range spans {
+		for _, w := range strings.Fields(s.text) {
+			words = append(words, word{text: w, color: s.color})
+		}
+	}
+
+	var lines []string
+	var line strings.Builder
+	lineWidth := 0
+	flush := func() {
+		lines = append(lines, line.String())
+		line.Reset()
+		lineWidth = 0
+	}
+	for _, w := range words {
+		ww := DisplayWidth(w.text)
+		if width > 0 && lineWidth > 0 && lineWidth+1+ww > width {
+			flush()
+		}
+		for width > 0 && lineWidth == 0 && ww > width {
+			head, tail := splitAtWidth(w.text, width)
+			line.WriteString(paint(w.color, head))
+			flush()
+			w.text, ww = tail, DisplayWidth(tail)
+		}
+		if w.text == "" {
+			continue
+		}
+		if lineWidth > 0 {
+			line.WriteString(" ")
+			lineWidth++
+		}
+		line.WriteString(paint(w.color, w.text))
+		lineWidth += ww
+	}
+	if lineWidth > 0 || len(lines) == 0 {
+		flush()
+	}
+	return lines
+}
+
+func splitAtWidth(s string, width int) (string, string) {
+	w := 0
+	for i, r := range s {
+		rw := RuneWidth(r)
+		if w+rw > width && i > 0 {
+			return s[:i], s[i:]
+		}
+		w += rw
+	}
+	return s, ""
+}
+
+func paint(c *color.Color, s string) string {
+	if c == nil {
+		return s
+	}
+	return c.Sprint(s)
+}
+
+func truncateWidth(s string, width int) string {
+	if DisplayWidth(s) <= width {
+		return s
+	}
+	if width < 1 {
+		return ""
+	}
+	head, _ := splitAtWidth(s, width-1)
+	return head + "…"
+}
+
+// clipWidth сохраняет ANSI-коды, чтобы цвет закрылся; нужен, когда не влезает даже отступ.
+func clipWidth(s string, width int) string {
+	if DisplayWidth(s) <= width {
+		return s
+	}
+	var b strings.Builder
+	w := 0
+	for len(s) > 0 {
+		if loc := ansiSequence.FindStringIndex(s); loc != nil && loc[0] == 0 {
+			b.WriteString(s[:loc[1]])
+			s = s[loc[1]:]
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s)
+		if w+RuneWidth(r) <= width {
+			b.WriteRune(r)
+			w += RuneWidth(r)
+		}
+		s = s[size:]
+	}
+	return b.String()
+}
+
+func padRight(s string, width int) string {
+	if gap := width - DisplayWidth(s); gap > 0 {
+		return s + strings.Repeat(" ", gap)
+	}
+	return s
+}
@@ -0,0 +1,305 @@
+package formatter
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/xeniasokk/field-switcher/internal/ports"
+)
+
+var _ ports.FormatterPort = (*YAMLFormatter)(nil)
+
+const defaultYAMLLineWidth = 80
+
+// YAMLFormatter пишет схему JSONFormatter; длинные строки — блочными скалярами.
+type YAMLFormatter struct {
+	lineWidth int
+	catalog   Catalog
+}
+
+type YAMLOption func(*YAMLFormatter)
+
+func WithYAMLLineWidth(width int) YAMLOption {
+	return func(f *YAMLFormatter) {
+		f.lineWidth = width
+	}
+}
+
+func WithYAMLCatalog(catalog Catalog) YAMLOption {
+	return func(f *YAMLFormatter) {
+		f.catalog = catalog
+	}
+}
+
+func NewYAMLFormatter(opts ...YAMLOption) *YAMLFormatter {
+	f := &YAMLFormatter{lineWidth: defaultYAMLLineWidth}
+	for _, opt := range opts {
+		opt(f)
+	}
+	if f.lineWidth < 20 {
+		f.lineWidth = 20
+	}
+	return f
+}
+
+func (f *YAMLFormatter) Format(ctx context.Context, vm ports.ViewModel) (string, error) {
+	_ = ctx
+
+	doc := NewJSONDocument(f.catalog.Localize(vm.Document()))
+	w := &yamlWriter{width: f.lineWidth}
+
+	w.scalar(0, "schema_version", doc.SchemaVersion)
+	w.scalar(0, "title", doc.Title)
+	if doc.Childhood != nil {
+		w.key(0, "childhood")
+		w.childhood(1, *doc.Childhood)
+	}
+	if len(doc.Sources) > 0 {
+		w.key(0, "sources")
+		for _, s := range doc.Sources {
+			w.item(1)
+			w.childhood(1, s)
+		}
+	}
+	if a := doc.Adult; a != nil {
+		w.key(0, "adult")
+		w.scalar(1, "role_title", a.RoleTitle)
+		if a.RoleDescription != "" {
+			w.scalar(1, "role_description", a.RoleDescription)
+		}
+		if a.Field != nil {
+			w.field(1, *a.Field)
+		}
+		w.strings(1, "stack", a.Stack)
+		w.qualities(1, "traits", a.Traits)
+	}
+	if doc.Note != "" {
+		w.scalar(0, "note", doc.Note)
+	}
+	if doc.Comment != "" {
+		w.scalar(0, "comment", doc.Comment)
+	}
+
+	return w.b.String(), nil
+}
+
+type yamlWriter struct {
+	b     strings.Builder
+	width int
+	// inItem: следующий ключ пишется на строке с "- "
+	inItem bool
+}
+
+func (w *yamlWriter) indent(level int) {
+	if w.inItem {
+		w.inItem = false
+		return
+	}
+	w.b.WriteString(strings.Repeat("  ", level))
+}
+
+func (w *yamlWriter) item(level int) {
+	w.b.WriteString(strings.Repeat("  ", level-1))
+	w.b.WriteString("- ")
+	w.inItem = true
+}
+
+func (w *yamlWriter) key(level int, key string) {
+	w.indent(level)
+	w.b.WriteString(key)
+	w.b.WriteString(":\n")
+}
+
+func (w *yamlWriter) scalar(level int, key, value string) {
+	w.indent(level)
+	w.b.WriteString(key)
+	w.b.WriteString(":")
+	w.value(level, len(key)+2, value)
+}
+
+func (w *yamlWriter) value(level, prefix int, value string) {
+	switch {
+	case strings.Contains(value, "\n") && !strings.HasPrefix(value, " "):
+		w.literal(level+1, value)
+	case 2*level+prefix+utf8.RuneCountInString(value) > w.width && foldable(value):
+		w.folded(level+1, value)
+	default:
+		w.b.WriteString(" ")
+		w.b.WriteString(yamlQuote(value))
+		w.b.WriteString("\n")
+	}
+}
+
+func (w *yamlWriter) literal(level int, value string) {
+	body := strings.TrimRight(value, "\n")
+	trailing := len(value) - len(body)
+	switch trailing {
+	case 0:
+		w.b.WriteString(" |-\n")
+	case 1:
+		w.b.WriteString(" |\n")
+	default:
+		w.b.WriteString(" |+\n")
+	}
+	pad := strings.Repeat("  ", level)
+	for _, line := range strings.Split(body, "\n") {
+		if line != "" {
+			w.b.WriteString(pad)
+			w.b.WriteString(line)
+		}
+		w.b.WriteString("\n")
+	}
+	for i := 1; i < trailing; i++ {
+		w.b.WriteString("\n")
+	}
+}
+
+func (w *yamlWriter) folded(level int, value string) {
+	w.b.WriteString(" >-\n")
+	pad := strings.Repeat("  ", level)
+	limit := max(w.width-len(pad), 20)
+
+	line := 0
+	w.b.WriteString(pad)
+	for i, word := range strings.Split(value, " ") {
+		n := utf8.RuneCountInString(word)
+		if i > 0 {
+			if line+1+n > limit {
+				w.b.WriteString("\n")
+				w.b.WriteString(pad)
+				line = 0
+			} else {
+				w.b.WriteString(" ")
+				line++
+			}
+		}
+		w.b.WriteString(word)
+		line += n
+	}
+	w.b.WriteString("\n")
+}
+
+func (w *yamlWriter) field(level int, f JSONField) {
+	w.key(level, "field")
+	w.scalar(level+1, "name", f.Name)
+	w.scalar(level+1, "environment", f.Environment)
+}
+
+func (w *yamlWriter) childhood(level int, c JSONChildhood) {
+	w.scalar(level, "type", c.Type)
+	w.scalar(level, "display_name", c.DisplayName)
+	w.scalar(level, "desired_role", c.DesiredRole)
+	w.field(level, c.Field)
+	w.qualities(level, "qualities", c.Qualities)
+}
+
+func (w *yamlWriter) strings(level int, key string, values []string) {
+	if len(values) == 0 {
+		w.indent(level)
+		w.b.WriteString(key)
+		w.b.WriteString(": []\n")
+		return
+	}
+	w.key(level, key)
+	for _, v := range values {
+		w.b.WriteString(strings.Repeat("  ", level))
+		w.b.WriteString("-")
+		w.value(level, 2, v)
+	}
+}
+
+func (w *yamlWriter) qualities(level int, key string, qualities []JSONQuality) {
+	if len(qualities) == 0 {
+		w.indent(level)
+		w.b.WriteString(key)
+		w.b.WriteString(": []\n")
+		return
+	}
+	w.key(level, key)
+	for _, q := range qualities {
+		w.item(level + 1)
+		w.scalar(level+1, "name", q.Name)
+		w.scalar(level+1, "description", q.Description)
+		if q.Intensity != 0 {
+			w.indent(level + 1)
+			w.b.WriteString("intensity: ")
+			w.b.WriteString(strconv.Itoa(q.Intensity))
+			w.b.WriteString("\n")
+		}
+		if len(q.Sources) > 0 {
+			w.strings(level+1, "sources", q.Sources)
+		}
+		w.indent(level + 1)
+		w.b.WriteString("highlighted: ")
+		w.b.WriteString(strconv.FormatBool(q.Highlighted))
+		w.b.WriteString("\n")
+	}
+}
+
+// foldable: строку можно перенести по пробелам без потери содержимого.
+func foldable(s string) bool {
+	return s != "" &&
+		!strings.ContainsAny(s, "\n\t") &&
+		!strings.Contains(s, "  ") &&
+		strings.TrimSpace(s) == s
+}
+
+var yamlReserved = map[string]bool{
+	"true": true, "false": true, "yes": true, "no": true, "on": true, "off": true,
+	"y": true, "n": true, "null": true, "~": true,
+}
+
+func yamlQuote(s string) string {
+	if yamlPlainSafe(s) {
+		return s
+	}
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				_, _ = fmt.Fprintf(&b, `\x%02x`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+func yamlPlainSafe(s string) bool {
+	if s == "" || strings.TrimSpace(s) != s {
+		return false
+	}
+	if yamlReserved[strings.ToLower(s)] {
+		return false
+	}
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return false
+	}
+	if strings.ContainsAny(s[:1], "-?:,[]{}#&*!|>'\"%@`0123456789+.") {
+		return false
+	}
+	if strings.Contains(s, ": ") || strings.Contains(s, " #") || strings.HasSuffix(s, ":") {
+		return false
+	}
+	for _, r := range s {
+		if r < 0x20 || r == 0x7f {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,39 @@
+package presenter
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/xeniasokk/field-switcher/internal/ports"
+	"github.com/xeniasokk/field-switcher/pkg/document"
+)
+
+var _ ports.PresenterPort = (*CompactPresenter)(nil)
+
+type CompactPresenter struct{}
+
+func NewCompactPresenter() *CompactPresenter {
+	return &CompactPresenter{}
+}
+
+func (p *CompactPresenter) Present(
+	ctx context.Context,
+	output ports.OutputModel,
+) (ports.ViewModel, error) {
+	if err := validateOutput(output); err != nil {
+		return ConsoleViewModel{}, err
+	}
+
+	adult := output.Adult()
+	// описание обычно кончается точкой, а формат строки ставит свою
+	summary := document.Message(keySummaryLine,
+		adult.RoleTitle(),
+		strings.TrimRight(adult.RoleDescription(), "."),
+		adult.Field().Name(),
+		adult.Field().Environment(),
+		strconv.Itoa(len(adult.Traits())),
+	)
+	vm := NewConsoleViewModel("", output.Child(), adult, "").WithTitle(summary)
+	return vm.WithDocument(document.Document{Title: summary}), nil
+}
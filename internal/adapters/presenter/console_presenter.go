@@ -2,11 +2,12 @@ package presenter
 
 import (
 	"context"
-	"fmt"
 	"slices"
+	"strconv"
 
 	"github.com/xeniasokk/field-switcher/internal/domain/dream"
 	"github.com/xeniasokk/field-switcher/internal/ports"
+	"github.com/xeniasokk/field-switcher/pkg/document"
 	appErrors "github.com/xeniasokk/field-switcher/pkg/errors"
 )
 
@@ -14,14 +15,14 @@ var _ ports.PresenterPort = (*ConsolePresenter)(nil)
 var _ ports.ViewModel = ConsoleViewModel{}
 
 type ConsoleViewModel struct {
-	title     string
-	childhood dream.ChildhoodDream
-	adult     dream.Adult
-	note      string
-}
-
-func (vm ConsoleViewModel) Title() string {
-	return vm.title
+	title      document.Text
+	childhood  dream.ChildhoodDream
+	adult      dream.Adult
+	notes      []document.Text
+	highlights []string
+	sources    []dream.ChildhoodDream
+	// document заменяет собранный по умолчанию документ в сокращённых режимах
+	document *document.Document
 }
 
 func (vm ConsoleViewModel) Childhood() dream.ChildhoodDream {
@@ -32,8 +33,38 @@ func (vm ConsoleViewModel) Adult() dream.Adult {
 	return vm.adult
 }
 
-func (vm ConsoleViewModel) Note() string {
-	return vm.note
+func (vm ConsoleViewModel) highlighted(name string) bool {
+	return slices.Contains(vm.highlights, name)
+}
+
+func (vm ConsoleViewModel) WithHighlights(names ...string) ConsoleViewModel {
+	vm.highlights = append(slices.Clone(vm.highlights), names...)
+	return vm
+}
+
+// Последующие WithNote и WithHighlights не меняют документ.
+func (vm ConsoleViewModel) WithDocument(doc document.Document) ConsoleViewModel {
+	vm.document = &doc
+	return vm
+}
+
+func (vm ConsoleViewModel) WithTitle(title document.Text) ConsoleViewModel {
+	vm.title = title
+	return vm
+}
+
+func (vm ConsoleViewModel) WithNote(note document.Text) ConsoleViewModel {
+	vm.notes = append(slices.Clone(vm.notes), note)
+	return vm
+}
+
+func (vm ConsoleViewModel) Sources() []dream.ChildhoodDream {
+	return slices.Clone(vm.sources)
+}
+
+func (vm ConsoleViewModel) WithSources(sources []dream.ChildhoodDream) ConsoleViewModel {
+	vm.sources = slices.Clone(sources)
+	return vm
 }
 
 func NewConsoleViewModel(
@@ -42,53 +73,78 @@ func NewConsoleViewModel(
 	adult dream.Adult,
 	note string,
 ) ConsoleViewModel {
-	return ConsoleViewModel{
-		title:     title,
+	vm := ConsoleViewModel{
+		title:     document.Plain(title),
 		childhood: childhood,
 		adult:     adult,
-		note:      note,
 	}
+	if note != "" {
+		vm.notes = []document.Text{document.Plain(note)}
+	}
+	return vm
 }
 
-type ConsolePresenter struct{}
+type ConsolePresenter struct {
+	highlights []HighlightRule
+}
+
+type ConsolePresenterOption func(*ConsolePresenter)
+
+// WithHighlightRules заменяет правила по умолчанию; пустой список отключает подсветку.
+func WithHighlightRules(rules []HighlightRule) ConsolePresenterOption {
+	return func(p *ConsolePresenter) {
+		p.highlights = slices.Clone(rules)
+	}
+}
 
-func NewConsolePresenter() *ConsolePresenter {
-	return &ConsolePresenter{}
+func NewConsolePresenter(opts ...ConsolePresenterOption) *ConsolePresenter {
+	p := &ConsolePresenter{highlights: DefaultHighlightRules()}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
 }
 
 func (p *ConsolePresenter) Present(
 	ctx context.Context,
 	output ports.OutputModel,
 ) (ports.ViewModel, error) {
-	_ = ctx
+	return p.present(ctx, output)
+}
 
-	adult := output.Adult()
-	if adult.RoleTitle() == "" {
-		return ConsoleViewModel{}, appErrors.NewDomainError("adult identity has empty role title")
-	}
+func (p *ConsolePresenter) present(ctx context.Context, output ports.OutputModel) (ConsoleViewModel, error) {
+	_ = ctx
 
-	child := output.Child()
-	if child.DisplayName() == "" {
-		return ConsoleViewModel{}, appErrors.NewDomainError("childhood dream has empty display name")
+	if err := validateOutput(output); err != nil {
+		return ConsoleViewModel{}, err
 	}
+	child, adult := output.Child(), output.Adult()
 
-	// Проверяем наличие упорства
-	traits := adult.Traits()
-	hasPersistence := slices.ContainsFunc(traits, func(q dream.Quality) bool {
-		return q.Name() == dream.QualityPersistence
-	})
+	vm := NewConsoleViewModel("", child, adult, "").
+		WithTitle(document.Message(keyAppTitle)).
+		WithNote(document.Message(keyPreservedCount, strconv.Itoa(len(adult.Traits()))))
 
-	note := fmt.Sprintf("Сохранено качеств: %d", len(adult.Traits()))
-	if hasPersistence {
-		note += fmt.Sprintf(" | %s — твой главный союзник на новом поле", dream.QualityPersistence)
+	children := []dream.ChildhoodDream{child}
+	if multi, ok := output.(ports.MultiSourceOutput); ok {
+		vm = vm.WithSources(multi.Sources())
+		children = append(children, multi.Sources()...)
 	}
 
-	vm := NewConsoleViewModel(
-		"field-switcher — трансформация мечты",
-		child,
-		adult,
-		note,
-	)
+	names, notes := applyHighlights(p.highlights, children, adult)
+	vm = vm.WithHighlights(names...)
+	for _, note := range notes {
+		vm = vm.WithNote(note)
+	}
 
 	return vm, nil
 }
+
+func validateOutput(output ports.OutputModel) error {
+	if output.Adult().RoleTitle() == "" {
+		return appErrors.NewDomainError("adult identity has empty role title")
+	}
+	if output.Child().DisplayName() == "" {
+		return appErrors.NewDomainError("childhood dream has empty display name")
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package presenter
+
+import (
+	"slices"
+
+	"github.com/xeniasokk/field-switcher/internal/adapters/formatter"
+	"github.com/xeniasokk/field-switcher/internal/domain/dream"
+	"github.com/xeniasokk/field-switcher/pkg/document"
+)
+
+// Подписи и фразы документа — ключи каталога сообщений форматтеров.
+const (
+	keySources     = string(formatter.MsgSources)
+	keyDreams      = string(formatter.MsgDreams)
+	keyChildhood   = string(formatter.MsgChildhood)
+	keyAdult       = string(formatter.MsgAdult)
+	keyName        = string(formatter.MsgName)
+	keyRole        = string(formatter.MsgRole)
+	keyField       = string(formatter.MsgField)
+	keyEnvironment = string(formatter.MsgEnvironment)
+	keyDescription = string(formatter.MsgDescription)
+	keyQualities   = string(formatter.MsgQualities)
+	keyTraits      = string(formatter.MsgTraits)
+	keyStack       = string(formatter.MsgStack)
+	keyComment     = string(formatter.MsgComment)
+	keyFromDreams  = string(formatter.MsgFromDreams)
+
+	keyAppTitle       = string(formatter.MsgAppTitle)
+	keyPreservedCount = string(formatter.MsgPreservedCount)
+	keyMainAlly       = string(formatter.MsgMainAlly)
+	keySummaryLine    = string(formatter.MsgSummaryLine)
+)
+
+// Документ, заданный через WithDocument, возвращается как есть.
+func (vm ConsoleViewModel) Document() document.Document {
+	if vm.document != nil {
+		return *vm.document
+	}
+	doc := document.Document{Title: vm.title}
+
+	if len(vm.sources) > 1 {
+		items := make([]document.Item, 0, len(vm.sources))
+		for _, s := range vm.sources {
+			items = append(items, document.Item{
+				Name:   document.Plain(s.DisplayName()),
+				Detail: document.Plain(s.DesiredRole()),
+				Attrs:  dreamAttrs(s),
+				Blocks: vm.childhoodBlocks(s),
+			})
+		}
+		doc.Sections = append(doc.Sections, document.Section{
+			Key:    keySources,
+			Blocks: []document.Block{document.List{Label: keyDreams, Items: items}},
+		})
+	}
+
+	doc.Sections = append(doc.Sections, document.Section{
+		Key:    keyChildhood,
+		Attrs:  dreamAttrs(vm.childhood),
+		Blocks: vm.childhoodBlocks(vm.childhood),
+	})
+
+	adult := vm.adult
+	fields := document.Fields{{Label: keyRole, Value: document.Plain(adult.RoleTitle())}}
+	if desc := adult.RoleDescription(); desc != "" {
+		fields = append(fields, document.Field{Label: keyDescription, Value: document.Plain(desc)})
+	}
+	fields = append(fields, document.Field{Label: keyField, Value: fieldText(adult.Field())})
+	adultSection := document.Section{Key: keyAdult, Blocks: []document.Block{fields}}
+	if stack := adult.Stack(); len(stack) > 0 {
+		adultSection.Blocks = append(adultSection.Blocks, vm.stackList(stack))
+	}
+	if traits := adult.Traits(); len(traits) > 0 {
+		adultSection.Blocks = append(adultSection.Blocks, vm.qualityList(keyTraits, traits))
+	}
+	doc.Sections = append(doc.Sections, adultSection)
+
+	doc.Notes = slices.Clone(vm.notes)
+	if comment := adult.Comment(); comment != "" {
+		doc.Quotes = append(doc.Quotes, document.Quote{Label: keyComment, Text: document.Plain(comment)})
+	}
+	return doc
+}
+
+func dreamAttrs(d dream.ChildhoodDream) map[string]string {
+	return map[string]string{document.AttrType: string(d.Type())}
+}
+
+func (vm ConsoleViewModel) childhoodBlocks(child dream.ChildhoodDream) []document.Block {
+	blocks := []document.Block{document.Fields{
+		{Label: keyName, Value: document.Plain(child.DisplayName())},
+		{Label: keyRole, Value: document.Plain(child.DesiredRole())},
+		{Label: keyField, Value: fieldText(child.Field())},
+	}}
+	if qualities := child.Qualities(); len(qualities) > 0 {
+		blocks = append(blocks, vm.qualityList(keyQualities, qualities))
+	}
+	return blocks
+}
+
+func fieldText(f dream.Field) document.Text {
+	text := document.Plain(f.Name())
+	if env := f.Environment(); env != "" {
+		text = append(text, document.Span{Text: env, Emphasis: document.EmphasisMuted, Label: keyEnvironment})
+	}
+	return text
+}
+
+func (vm ConsoleViewModel) qualityList(label string, qualities []dream.Quality) document.List {
+	items := make([]document.Item, 0, len(qualities))
+	for _, q := range qualities {
+		emphasis := document.EmphasisTerm
+		if vm.highlighted(q.Name()) {
+			emphasis = document.EmphasisHighlight
+		}
+		items = append(items, document.Item{
+			Name:   document.Emphasized(q.Name(), emphasis),
+			Detail: document.Plain(q.Description()),
+			Refs:   q.Sources(),
+			Weight: q.Intensity(),
+		})
+	}
+	return document.List{Label: label, RefsLabel: keyFromDreams, Items: items}
+}
+
+func (vm ConsoleViewModel) stackList(stack []string) document.List {
+	items := make([]document.Item, 0, len(stack))
+	for _, s := range stack {
+		emphasis := document.EmphasisCode
+		if vm.highlighted(s) {
+			emphasis = document.EmphasisHighlight
+		}
+		items = append(items, document.Item{Name: document.Emphasized(s, emphasis)})
+	}
+	return document.List{Label: keyStack, Items: items}
+}
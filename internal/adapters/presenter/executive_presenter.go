@@ -0,0 +1,45 @@
+package presenter
+
+import (
+	"context"
+
+	"github.com/xeniasokk/field-switcher/internal/domain/dream"
+	"github.com/xeniasokk/field-switcher/internal/ports"
+	"github.com/xeniasokk/field-switcher/pkg/document"
+)
+
+var _ ports.PresenterPort = (*ExecutivePresenter)(nil)
+
+const executiveQualities = 3
+
+// ExecutivePresenter не включает детскую мечту и комментарий.
+type ExecutivePresenter struct {
+	detailed *ConsolePresenter
+}
+
+func NewExecutivePresenter(opts ...ConsolePresenterOption) *ExecutivePresenter {
+	return &ExecutivePresenter{detailed: NewConsolePresenter(opts...)}
+}
+
+func (p *ExecutivePresenter) Present(
+	ctx context.Context,
+	output ports.OutputModel,
+) (ports.ViewModel, error) {
+	vm, err := p.detailed.present(ctx, output)
+	if err != nil {
+		return ConsoleViewModel{}, err
+	}
+
+	adult := vm.adult
+	section := document.Section{
+		Key:    keyAdult,
+		Blocks: []document.Block{document.Fields{{Label: keyRole, Value: document.Plain(adult.RoleTitle())}}},
+	}
+	if traits := dream.RankByIntensity(adult.Traits()); len(traits) > 0 {
+		section.Blocks = append(section.Blocks, vm.qualityList(keyTraits, traits[:min(len(traits), executiveQualities)]))
+	}
+	if stack := adult.Stack(); len(stack) > 0 {
+		section.Blocks = append(section.Blocks, vm.stackList(stack))
+	}
+	return vm.WithDocument(document.Document{Title: vm.title, Sections: []document.Section{section}}), nil
+}
@@ -0,0 +1,110 @@
+package presenter
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/xeniasokk/field-switcher/internal/application/validation"
+	"github.com/xeniasokk/field-switcher/internal/domain/dream"
+	"github.com/xeniasokk/field-switcher/pkg/document"
+	appErrors "github.com/xeniasokk/field-switcher/pkg/errors"
+)
+
+// HighlightRule добавляет Note, если у роли нашлось хоть одно из перечисленных имён.
+type HighlightRule struct {
+	Qualities []string `json:"qualities,omitempty"`
+	Stack     []string `json:"stack,omitempty"`
+	Note      string   `json:"note,omitempty"`
+	// noteKey заменяет Note у встроенных правил
+	noteKey string
+}
+
+func DefaultHighlightRules() []HighlightRule {
+	return []HighlightRule{{
+		Qualities: []string{dream.QualityPersistence},
+		noteKey:   keyMainAlly,
+	}}
+}
+
+func ParseHighlightRules(data []byte) ([]HighlightRule, error) {
+	var rules []HighlightRule
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, appErrors.Wrap(err, appErrors.CodeValidation, "parse highlight rules")
+	}
+	for i, rule := range rules {
+		if len(rule.Qualities) == 0 && len(rule.Stack) == 0 {
+			return nil, appErrors.NewValidationError(fmt.Sprintf("highlight rule %d matches nothing", i))
+		}
+	}
+	return rules, nil
+}
+
+func LoadHighlightRulesFile(path string) ([]HighlightRule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.CodeIO, fmt.Sprintf("read highlight rules file %s", path))
+	}
+	return ParseHighlightRules(data)
+}
+
+// applyHighlights сравнивает имена так же, как валидация; заметки дают только правила,
+// сработавшие на взрослой роли.
+func applyHighlights(
+	rules []HighlightRule,
+	children []dream.ChildhoodDream,
+	adult dream.Adult,
+) ([]string, []document.Text) {
+	var qualities []string
+	for _, c := range children {
+		for _, q := range c.Qualities() {
+			qualities = append(qualities, q.Name())
+		}
+	}
+	var traits []string
+	for _, q := range adult.Traits() {
+		traits = append(traits, q.Name())
+	}
+
+	var names []string
+	var notes []document.Text
+	add := func(found []string) {
+		for _, name := range found {
+			if !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	for _, rule := range rules {
+		matchedTraits := matchNames(rule.Qualities, traits)
+		matchedStack := matchNames(rule.Stack, adult.Stack())
+		add(matchNames(rule.Qualities, qualities))
+		add(matchedTraits)
+		add(matchedStack)
+
+		matched := append(matchedTraits, matchedStack...)
+		switch {
+		case len(matched) == 0:
+		case rule.noteKey != "":
+			notes = append(notes, document.Text{
+				{Key: rule.noteKey, Args: []string{matched[0]}, Emphasis: document.EmphasisHighlight},
+			})
+		case rule.Note != "":
+			notes = append(notes, document.Emphasized(rule.Note, document.EmphasisHighlight))
+		}
+	}
+	return names, notes
+}
+
+func matchNames(patterns, names []string) []string {
+	var found []string
+	for _, name := range names {
+		if slices.ContainsFunc(patterns, func(p string) bool {
+			return validation.Normalize(p) == validation.Normalize(name)
+		}) {
+			found = append(found, name)
+		}
+	}
+	return found
+}
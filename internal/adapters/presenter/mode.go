@@ -0,0 +1,49 @@
+package presenter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/xeniasokk/field-switcher/internal/ports"
+	appErrors "github.com/xeniasokk/field-switcher/pkg/errors"
+)
+
+type Mode string
+
+const (
+	ModeCompact   Mode = "compact"
+	ModeDetailed  Mode = "detailed"
+	ModeExecutive Mode = "executive"
+
+	DefaultMode = ModeDetailed
+)
+
+func Modes() []string {
+	return []string{string(ModeCompact), string(ModeDetailed), string(ModeExecutive)}
+}
+
+func ParseMode(s string) (Mode, error) {
+	mode := Mode(strings.ToLower(strings.TrimSpace(s)))
+	switch mode {
+	case ModeCompact, ModeDetailed, ModeExecutive:
+		return mode, nil
+	default:
+		return "", appErrors.NewValidationError(
+			fmt.Sprintf("unknown presenter mode %q, available: %s", s, strings.Join(Modes(), ", ")),
+		)
+	}
+}
+
+// NewPresenter: пустой режим — DefaultMode; компактный режим игнорирует опции подсветки.
+func NewPresenter(mode Mode, opts ...ConsolePresenterOption) (ports.PresenterPort, error) {
+	switch mode {
+	case ModeCompact:
+		return NewCompactPresenter(), nil
+	case ModeDetailed, "":
+		return NewConsolePresenter(opts...), nil
+	case ModeExecutive:
+		return NewExecutivePresenter(opts...), nil
+	default:
+		return nil, appErrors.NewValidationError(fmt.Sprintf("unknown presenter mode %q", mode))
+	}
+}
@@ -2,11 +2,20 @@ package tests
 
 import (
 	"context"
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
 	"testing"
 
+	"github.com/xeniasokk/field-switcher/internal/adapters/formatter"
 	"github.com/xeniasokk/field-switcher/internal/adapters/presenter"
 	"github.com/xeniasokk/field-switcher/internal/application/usecase/transform"
 	"github.com/xeniasokk/field-switcher/internal/domain/dream"
+	"github.com/xeniasokk/field-switcher/internal/ports"
+	"github.com/xeniasokk/field-switcher/pkg/document"
+	appErrors "github.com/xeniasokk/field-switcher/pkg/errors"
 )
 
 func TestConsolePresenterPresent(t *testing.T) {
@@ -70,3 +79,502 @@ func TestConsolePresenterPresent(t *testing.T) {
 		})
 	}
 }
+
+func TestConsoleViewModelDocument(t *testing.T) {
+	child, err := dream.NewDefaultFootballerDream()
+	if err != nil {
+		t.Fatalf("failed to create default footballer dream: %v", err)
+	}
+	f, _ := dream.NewField("Dev", "Team")
+	a, _ := dream.NewAdult("Role", "", f, []string{"Go"}, child.Qualities(), "comment")
+
+	tests := []struct {
+		name      string
+		vm        presenter.ConsoleViewModel
+		wantKeys  []string
+		wantNotes []document.Text
+	}{
+		{
+			name: "single dream",
+			vm: presenter.NewConsoleViewModel("title", child, a, "Сохранено качеств: 5").
+				WithHighlights(dream.QualityPersistence).
+				WithNote(document.Emphasized("Упорство — союзник", document.EmphasisHighlight)),
+			wantKeys: []string{"childhood", "adult"},
+			wantNotes: []document.Text{
+				document.Plain("Сохранено качеств: 5"),
+				document.Emphasized("Упорство — союзник", document.EmphasisHighlight),
+			},
+		},
+		{
+			name: "merged dreams get a sources section",
+			vm: presenter.NewConsoleViewModel("title", child, a, "").
+				WithHighlights(dream.QualityPersistence).
+				WithSources([]dream.ChildhoodDream{child, child}),
+			wantKeys: []string{"sources", "childhood", "adult"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := tt.vm.Document()
+			var keys []string
+			for _, s := range doc.Sections {
+				keys = append(keys, s.Key)
+			}
+			if !reflect.DeepEqual(keys, tt.wantKeys) {
+				t.Fatalf("section keys = %v, want %v", keys, tt.wantKeys)
+			}
+			if !reflect.DeepEqual(doc.Notes, tt.wantNotes) {
+				t.Fatalf("notes = %v, want %v", doc.Notes, tt.wantNotes)
+			}
+			if len(doc.Quotes) != 1 || doc.Quotes[0].Text.String() != "comment" {
+				t.Fatalf("expected the comment as a quote, got %v", doc.Quotes)
+			}
+
+			adult := doc.Sections[len(doc.Sections)-1]
+			var highlighted []string
+			for _, block := range adult.Blocks {
+				if list, ok := block.(document.List); ok {
+					for _, item := range list.Items {
+						if item.Name.Emphasis() == document.EmphasisHighlight {
+							highlighted = append(highlighted, item.Name.String())
+						}
+					}
+				}
+			}
+			if !reflect.DeepEqual(highlighted, []string{dream.QualityPersistence}) {
+				t.Fatalf("highlighted items = %v, want only %s", highlighted, dream.QualityPersistence)
+			}
+		})
+	}
+}
+
+func TestConsolePresenterHighlightRules(t *testing.T) {
+	ctx := context.Background()
+	child, err := dream.NewDefaultFootballerDream()
+	if err != nil {
+		t.Fatalf("failed to create default footballer dream: %v", err)
+	}
+	f, _ := dream.NewField("Dev", "Team")
+	a, _ := dream.NewAdult("Role", "", f, []string{"Go", "Kafka"}, child.Qualities()[:1], "")
+	persistence, _ := dream.NewQuality(dream.QualityPersistence, "не сдаётся")
+	persistent, _ := dream.NewAdult("Role", "", f, nil, []dream.Quality{persistence}, "")
+
+	tests := []struct {
+		name        string
+		opts        []presenter.ConsolePresenterOption
+		highlighted []string
+		adult       dream.Adult
+		plain       []string
+		wantNote    string
+	}{
+		{
+			name:        "default rule highlights persistence without a note when the role lacks it",
+			highlighted: []string{dream.QualityPersistence},
+			plain:       []string{"Go", child.Qualities()[0].Name()},
+			wantNote:    "Сохранено качеств: 1",
+		},
+		{
+			name: "custom rule matches the stack",
+			opts: []presenter.ConsolePresenterOption{presenter.WithHighlightRules([]presenter.HighlightRule{
+				{Stack: []string{"Kafka"}, Note: "Kafka — редкий навык"},
+			})},
+			highlighted: []string{"Kafka"},
+			plain:       []string{"Go", dream.QualityPersistence},
+			wantNote:    "Сохранено качеств: 1 | Kafka — редкий навык",
+		},
+		{
+			name:        "default rule adds a note when the role keeps persistence",
+			opts:        []presenter.ConsolePresenterOption{presenter.WithHighlightRules(presenter.DefaultHighlightRules())},
+			adult:       persistent,
+			highlighted: []string{dream.QualityPersistence},
+			wantNote:    "Сохранено качеств: 1 | Упорство — твой главный союзник на новом поле",
+		},
+		{
+			name: "rule names are compared after normalization",
+			opts: []presenter.ConsolePresenterOption{presenter.WithHighlightRules([]presenter.HighlightRule{
+				{Qualities: []string{"  упорство "}, Note: "нашлось"},
+			})},
+			adult:       persistent,
+			highlighted: []string{dream.QualityPersistence},
+			wantNote:    "Сохранено качеств: 1 | нашлось",
+		},
+		{
+			name: "absent names are neither highlighted nor noted",
+			opts: []presenter.ConsolePresenterOption{presenter.WithHighlightRules([]presenter.HighlightRule{
+				{Qualities: []string{"Rust"}, Stack: []string{"Rust"}, Note: "Rust — редкий навык"},
+			})},
+			plain:    []string{"Rust", "Go", dream.QualityPersistence},
+			wantNote: "Сохранено качеств: 1",
+		},
+		{
+			name:     "empty rules disable highlighting",
+			opts:     []presenter.ConsolePresenterOption{presenter.WithHighlightRules(nil)},
+			plain:    []string{dream.QualityPersistence, "Kafka"},
+			wantNote: "Сохранено качеств: 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adult := a
+			if tt.adult.RoleTitle() != "" {
+				adult = tt.adult
+			}
+			vm, err := presenter.NewConsolePresenter(tt.opts...).Present(ctx, transform.NewOutput(child, adult))
+			if err != nil {
+				t.Fatalf("Present() error = %v", err)
+			}
+			highlighted := highlightedNames(vm.Document())
+			for _, name := range tt.highlighted {
+				if !highlighted[name] {
+					t.Errorf("expected %q to be highlighted", name)
+				}
+			}
+			for _, name := range tt.plain {
+				if highlighted[name] {
+					t.Errorf("expected %q not to be highlighted", name)
+				}
+			}
+			if got := localizedNote(vm.Document()); got != tt.wantNote {
+				t.Fatalf("note = %q, want %q", got, tt.wantNote)
+			}
+		})
+	}
+}
+
+func TestParseHighlightRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    []presenter.HighlightRule
+		wantErr bool
+	}{
+		{
+			name: "qualities and stack",
+			data: `[{"qualities": ["Упорство"], "note": "союзник"}, {"stack": ["Go"]}]`,
+			want: []presenter.HighlightRule{
+				{Qualities: []string{"Упорство"}, Note: "союзник"},
+				{Stack: []string{"Go"}},
+			},
+		},
+		{name: "rule without names", data: `[{"note": "ничего"}]`, wantErr: true},
+		{name: "broken json", data: `{`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := presenter.ParseHighlightRules([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseHighlightRules() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if !appErrors.IsCode(err, appErrors.CodeValidation) {
+					t.Fatalf("expected validation error, got %v", err)
+				}
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("rules = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPresenterModes(t *testing.T) {
+	ctx := context.Background()
+	child, err := dream.NewDefaultFootballerDream()
+	if err != nil {
+		t.Fatalf("failed to create default footballer dream: %v", err)
+	}
+	f, _ := dream.NewField("Dev", "Team")
+	a, _ := dream.NewAdult("Role", "Desc", f, []string{"Go"}, child.Qualities(), "comment")
+
+	tests := []struct {
+		name       string
+		mode       presenter.Mode
+		wantTitle  string
+		wantKeys   []string
+		wantTraits int
+		wantQuotes int
+	}{
+		{
+			name:      "compact is a single summary line",
+			mode:      presenter.ModeCompact,
+			wantTitle: "Role — Desc. Поле: Dev (Team). Качества: 5",
+		},
+		{
+			name:       "detailed keeps everything",
+			mode:       presenter.ModeDetailed,
+			wantTitle:  "field-switcher — трансформация мечты",
+			wantKeys:   []string{"childhood", "adult"},
+			wantTraits: 5,
+			wantQuotes: 1,
+		},
+		{
+			name:       "executive keeps role, top three qualities and stack",
+			mode:       presenter.ModeExecutive,
+			wantTitle:  "field-switcher — трансформация мечты",
+			wantKeys:   []string{"adult"},
+			wantTraits: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := presenter.NewPresenter(tt.mode)
+			if err != nil {
+				t.Fatalf("NewPresenter() error = %v", err)
+			}
+			vm, err := p.Present(ctx, transform.NewOutput(child, a))
+			if err != nil {
+				t.Fatalf("Present() error = %v", err)
+			}
+
+			doc := vm.Document()
+			if got := (formatter.Catalog{}).Localize(doc).Title.String(); got != tt.wantTitle {
+				t.Fatalf("title = %q, want %q", got, tt.wantTitle)
+			}
+			var keys []string
+			traits := 0
+			for _, s := range doc.Sections {
+				keys = append(keys, s.Key)
+				for _, block := range s.Blocks {
+					if list, ok := block.(document.List); ok && list.Label == "traits" {
+						traits = len(list.Items)
+					}
+				}
+			}
+			if !reflect.DeepEqual(keys, tt.wantKeys) {
+				t.Fatalf("section keys = %v, want %v", keys, tt.wantKeys)
+			}
+			if traits != tt.wantTraits {
+				t.Fatalf("traits = %d, want %d", traits, tt.wantTraits)
+			}
+			if len(doc.Quotes) != tt.wantQuotes {
+				t.Fatalf("quotes = %d, want %d", len(doc.Quotes), tt.wantQuotes)
+			}
+		})
+	}
+}
+
+// Разделы и поля, которых нет в режиме, в JSON опускаются, а не выводятся пустыми строками.
+func TestPresenterModesThroughJSON(t *testing.T) {
+	ctx := context.Background()
+	child, err := dream.NewDefaultFootballerDream()
+	if err != nil {
+		t.Fatalf("failed to create default footballer dream: %v", err)
+	}
+	f, _ := dream.NewField("Dev", "Team")
+	a, _ := dream.NewAdult("Role", "Desc.", f, []string{"Go"}, child.Qualities(), "comment")
+
+	tests := []struct {
+		mode      presenter.Mode
+		wantTitle string
+		wantKeys  []string
+		wantAdult []string
+	}{
+		{
+			mode:      presenter.ModeCompact,
+			wantTitle: "Role — Desc. Поле: Dev (Team). Качества: 5",
+			wantKeys:  []string{"schema_version", "title"},
+		},
+		{
+			mode:      presenter.ModeDetailed,
+			wantTitle: "field-switcher — трансформация мечты",
+			wantKeys:  []string{"adult", "childhood", "comment", "note", "schema_version", "title"},
+			wantAdult: []string{"field", "role_description", "role_title", "stack", "traits"},
+		},
+		{
+			mode:      presenter.ModeExecutive,
+			wantTitle: "field-switcher — трансформация мечты",
+			wantKeys:  []string{"adult", "schema_version", "title"},
+			wantAdult: []string{"role_title", "stack", "traits"},
+		},
+	}
+
+	keys := func(m map[string]any) []string {
+		var out []string
+		for k := range m {
+			out = append(out, k)
+		}
+		sort.Strings(out)
+		return out
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			p, err := presenter.NewPresenter(tt.mode)
+			if err != nil {
+				t.Fatalf("NewPresenter() error = %v", err)
+			}
+			vm, err := p.Present(ctx, transform.NewOutput(child, a))
+			if err != nil {
+				t.Fatalf("Present() error = %v", err)
+			}
+			out, err := formatter.NewJSONFormatter().Format(ctx, vm)
+			if err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+
+			var doc map[string]any
+			if err := json.Unmarshal([]byte(out), &doc); err != nil {
+				t.Fatalf("invalid JSON: %v", err)
+			}
+			if doc["title"] != tt.wantTitle {
+				t.Errorf("title = %q, want %q", doc["title"], tt.wantTitle)
+			}
+			if got := keys(doc); !reflect.DeepEqual(got, tt.wantKeys) {
+				t.Errorf("keys = %v, want %v", got, tt.wantKeys)
+			}
+			adult, _ := doc["adult"].(map[string]any)
+			if got := keys(adult); !reflect.DeepEqual(got, tt.wantAdult) {
+				t.Errorf("adult keys = %v, want %v", got, tt.wantAdult)
+			}
+		})
+	}
+}
+
+// Ни один формат не должен рисовать три режима одинаково.
+func TestPresenterModesChangeEveryFormat(t *testing.T) {
+	ctx := context.Background()
+	child, err := dream.NewDefaultFootballerDream()
+	if err != nil {
+		t.Fatalf("failed to create default footballer dream: %v", err)
+	}
+	f, _ := dream.NewField("Dev", "Team")
+	a, _ := dream.NewAdult("Role", "Desc", f, []string{"Go"}, child.Qualities(), "comment")
+
+	var vms []ports.ViewModel
+	for _, mode := range []presenter.Mode{presenter.ModeCompact, presenter.ModeDetailed, presenter.ModeExecutive} {
+		p, err := presenter.NewPresenter(mode)
+		if err != nil {
+			t.Fatalf("NewPresenter() error = %v", err)
+		}
+		vm, err := p.Present(ctx, transform.NewOutput(child, a))
+		if err != nil {
+			t.Fatalf("Present() error = %v", err)
+		}
+		vms = append(vms, vm)
+	}
+
+	registry := formatter.DefaultRegistry()
+	settings := formatter.Settings{TemplateFile: filepath.Join("..", "..", "..", "..", "docs", "templates", "summary.tmpl")}
+	for _, name := range registry.Names() {
+		t.Run(name, func(t *testing.T) {
+			fmtr, err := registry.New(name, settings)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			seen := make(map[string]int)
+			for i, vm := range vms {
+				out, err := fmtr.Format(ctx, vm)
+				if err != nil {
+					t.Fatalf("Format() error = %v", err)
+				}
+				if j, ok := seen[out]; ok {
+					t.Fatalf("mode %d renders the same as mode %d", i, j)
+				}
+				seen[out] = i
+			}
+		})
+	}
+}
+
+func TestExecutivePresenterRanksTraitsByIntensity(t *testing.T) {
+	ctx := context.Background()
+	child, err := dream.NewDefaultFootballerDream()
+	if err != nil {
+		t.Fatalf("failed to create default footballer dream: %v", err)
+	}
+	var traits []dream.Quality
+	for _, q := range []struct {
+		name      string
+		intensity int
+	}{{"Скорость", 2}, {"Упорство", 9}, {"Точность", 5}, {"Выносливость", 7}} {
+		trait, err := dream.NewQualityWithIntensity(q.name, "", q.intensity)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		traits = append(traits, trait)
+	}
+	f, _ := dream.NewField("Dev", "Team")
+	a, _ := dream.NewAdult("Role", "Desc", f, nil, traits, "")
+
+	vm, err := presenter.NewExecutivePresenter().Present(ctx, transform.NewOutput(child, a))
+	if err != nil {
+		t.Fatalf("Present() error = %v", err)
+	}
+	var got []string
+	for _, block := range vm.Document().Sections[0].Blocks {
+		if list, ok := block.(document.List); ok && list.Label == "traits" {
+			for _, item := range list.Items {
+				got = append(got, item.Name.String())
+			}
+		}
+	}
+	if want := []string{"Упорство", "Выносливость", "Точность"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("executive traits = %v, want %v", got, want)
+	}
+}
+
+func TestParseMode(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    presenter.Mode
+		wantErr bool
+	}{
+		{in: "compact", want: presenter.ModeCompact},
+		{in: " Executive ", want: presenter.ModeExecutive},
+		{in: "verbose", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := presenter.ParseMode(tt.in)
+			if tt.wantErr {
+				if !appErrors.IsCode(err, appErrors.CodeValidation) {
+					t.Fatalf("expected validation error, got %v", err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("ParseMode(%q) = %q, %v; want %q", tt.in, got, err, tt.want)
+			}
+		})
+	}
+}
+
+// localizedNote склеивает заметки, переведённые каталогом по умолчанию, как это делает CSV.
+func localizedNote(doc document.Document) string {
+	notes := (formatter.Catalog{}).Localize(doc).Notes
+	parts := make([]string, 0, len(notes))
+	for _, note := range notes {
+		parts = append(parts, note.String())
+	}
+	return strings.Join(parts, " | ")
+}
+
+// highlightedNames собирает имена пунктов документа, выделенные как EmphasisHighlight.
+func highlightedNames(doc document.Document) map[string]bool {
+	names := make(map[string]bool)
+	var walk func(blocks []document.Block)
+	walk = func(blocks []document.Block) {
+		for _, block := range blocks {
+			list, ok := block.(document.List)
+			if !ok {
+				continue
+			}
+			for _, item := range list.Items {
+				if item.Name.Emphasis() == document.EmphasisHighlight {
+					names[item.Name.String()] = true
+				}
+				walk(item.Blocks)
+			}
+		}
+	}
+	for _, section := range doc.Sections {
+		walk(section.Blocks)
+	}
+	return names
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/xeniasokk/field-switcher/internal/application/usecase/merge"
 	"github.com/xeniasokk/field-switcher/internal/application/usecase/transform"
 	"github.com/xeniasokk/field-switcher/internal/domain/dream"
 	"github.com/xeniasokk/field-switcher/internal/ports"
@@ -16,6 +17,16 @@ type ConsoleRunner struct {
 	presenter ports.PresenterPort
 	formatter ports.FormatterPort
 	out       io.Writer
+	merger    merge.UseCase
+}
+
+type ConsoleRunnerOption func(*ConsoleRunner)
+
+// WithMerger нужен для RunMerged.
+func WithMerger(uc merge.UseCase) ConsoleRunnerOption {
+	return func(r *ConsoleRunner) {
+		r.merger = uc
+	}
 }
 
 func NewConsoleRunner(
@@ -23,6 +34,7 @@ func NewConsoleRunner(
 	presenter ports.PresenterPort,
 	formatter ports.FormatterPort,
 	out io.Writer,
+	opts ...ConsoleRunnerOption,
 ) (*ConsoleRunner, error) {
 	if useCase == nil {
 		return nil, appErrors.NewInternalError("useCase cannot be nil in ConsoleRunner")
@@ -37,26 +49,52 @@ func NewConsoleRunner(
 		return nil, appErrors.NewInternalError("output writer cannot be nil in ConsoleRunner")
 	}
 
-	return &ConsoleRunner{
+	r := &ConsoleRunner{
 		useCase:   useCase,
 		presenter: presenter,
 		formatter: formatter,
 		out:       out,
-	}, nil
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r, nil
 }
 
 func (r *ConsoleRunner) Run(ctx context.Context, child dream.ChildhoodDream) error {
-	input := transform.NewInput(child)
-
-	output, err := r.useCase.Execute(ctx, input)
+	vm, err := r.present(ctx, child)
 	if err != nil {
-		return appErrors.Wrap(err, appErrors.CodeDomainFailure, "useCase execution failed")
+		return err
 	}
+	return r.write(ctx, vm)
+}
 
+// RunMerged сводит мечты в одну взрослую роль; вывод перечисляет все исходные мечты.
+func (r *ConsoleRunner) RunMerged(ctx context.Context, strategy merge.Strategy, children []dream.ChildhoodDream) error {
+	if r.merger == nil {
+		return appErrors.NewInternalError("merge use case is not configured in ConsoleRunner")
+	}
+	output, err := r.merger.Execute(ctx, merge.NewInput(strategy, children...))
+	if err != nil {
+		return appErrors.Wrap(err, appErrors.CodeDomainFailure, "merge use case execution failed")
+	}
 	vm, err := r.presenter.Present(ctx, output)
 	if err != nil {
 		return appErrors.Wrap(err, appErrors.CodeInternal, "presenter failed")
 	}
+	return r.write(ctx, vm)
+}
+
+func (r *ConsoleRunner) write(ctx context.Context, vm ports.ViewModel) error {
+	if sf, ok := r.formatter.(ports.StreamFormatterPort); ok {
+		if err := sf.FormatTo(ctx, r.out, vm); err != nil {
+			if appErrors.IsCode(err, appErrors.CodeIO) {
+				return appErrors.Wrap(err, appErrors.CodeIO, "failed to write formatted output")
+			}
+			return appErrors.Wrap(err, appErrors.CodeInternal, "formatter failed")
+		}
+		return nil
+	}
 
 	text, err := r.formatter.Format(ctx, vm)
 	if err != nil {
@@ -69,3 +107,52 @@ func (r *ConsoleRunner) Run(ctx context.Context, child dream.ChildhoodDream) err
 
 	return nil
 }
+
+// RunAll требует ports.BatchFormatterPort: таблицу или граф нельзя склеить из отдельных выводов.
+func (r *ConsoleRunner) RunAll(ctx context.Context, children []dream.ChildhoodDream) error {
+	batch, ok := r.formatter.(ports.BatchFormatterPort)
+	if !ok {
+		return appErrors.NewValidationError("formatter does not support batch output")
+	}
+
+	vms := make([]ports.ViewModel, 0, len(children))
+	for _, child := range children {
+		vm, err := r.present(ctx, child)
+		if err != nil {
+			return appErrors.Wrap(err, appErrors.CodeOf(err), fmt.Sprintf("dream %q", child.DisplayName()))
+		}
+		vms = append(vms, vm)
+	}
+
+	if sf, ok := batch.(ports.BatchStreamFormatterPort); ok {
+		if err := sf.FormatAllTo(ctx, r.out, vms); err != nil {
+			if appErrors.IsCode(err, appErrors.CodeIO) {
+				return appErrors.Wrap(err, appErrors.CodeIO, "failed to write formatted output")
+			}
+			return appErrors.Wrap(err, appErrors.CodeInternal, "formatter failed")
+		}
+		return nil
+	}
+
+	text, err := batch.FormatAll(ctx, vms)
+	if err != nil {
+		return appErrors.Wrap(err, appErrors.CodeInternal, "formatter failed")
+	}
+	if _, err := fmt.Fprint(r.out, text); err != nil {
+		return appErrors.Wrap(err, appErrors.CodeIO, "failed to write formatted output")
+	}
+	return nil
+}
+
+func (r *ConsoleRunner) present(ctx context.Context, child dream.ChildhoodDream) (ports.ViewModel, error) {
+	output, err := r.useCase.Execute(ctx, transform.NewInput(child))
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.CodeDomainFailure, "useCase execution failed")
+	}
+
+	vm, err := r.presenter.Present(ctx, output)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.CodeInternal, "presenter failed")
+	}
+	return vm, nil
+}
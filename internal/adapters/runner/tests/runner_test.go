@@ -3,24 +3,39 @@ package tests
 import (
 	"bytes"
 	"context"
+	"errors"
+	"io"
+	"strings"
 	"testing"
 
 	"github.com/xeniasokk/field-switcher/internal/adapters/formatter"
 	"github.com/xeniasokk/field-switcher/internal/adapters/presenter"
 	"github.com/xeniasokk/field-switcher/internal/adapters/runner"
 	"github.com/xeniasokk/field-switcher/internal/adapters/transformer"
+	"github.com/xeniasokk/field-switcher/internal/application/usecase/merge"
 	"github.com/xeniasokk/field-switcher/internal/application/usecase/transform"
 	"github.com/xeniasokk/field-switcher/internal/domain/dream"
+	"github.com/xeniasokk/field-switcher/internal/ports"
+	appErrors "github.com/xeniasokk/field-switcher/pkg/errors"
 )
 
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
 func TestConsoleRunnerRun(t *testing.T) {
 	ctx := context.Background()
 
 	tests := []struct {
 		name       string
 		targetRole dream.Role
+		formatter  ports.FormatterPort
+		out        io.Writer
 		wantOutput bool
 		wantErr    bool
+		wantCode   appErrors.Code
 	}{
 		{
 			name:       "run with developer role",
@@ -28,6 +43,21 @@ func TestConsoleRunnerRun(t *testing.T) {
 			wantOutput: true,
 			wantErr:    false,
 		},
+		{
+			name:       "streaming formatter write failure",
+			targetRole: dream.RoleDeveloper,
+			out:        failingWriter{},
+			wantErr:    true,
+			wantCode:   appErrors.CodeIO,
+		},
+		{
+			name:       "string formatter write failure",
+			targetRole: dream.RoleDeveloper,
+			formatter:  formatter.NewMarkdownFormatter(),
+			out:        failingWriter{},
+			wantErr:    true,
+			wantCode:   appErrors.CodeIO,
+		},
 	}
 
 	for _, tt := range tests {
@@ -39,11 +69,18 @@ func TestConsoleRunnerRun(t *testing.T) {
 
 			uc := transform.NewUseCase(tr)
 			p := presenter.NewConsolePresenter()
-			f := formatter.NewTextFormatter()
+			f := tt.formatter
+			if f == nil {
+				f = formatter.NewTextFormatter()
+			}
 
 			var buf bytes.Buffer
+			var out io.Writer = &buf
+			if tt.out != nil {
+				out = tt.out
+			}
 
-			r, err := runner.NewConsoleRunner(uc, p, f, &buf)
+			r, err := runner.NewConsoleRunner(uc, p, f, out)
 			if err != nil {
 				t.Fatalf("unexpected error: %v", err)
 			}
@@ -57,6 +94,9 @@ func TestConsoleRunnerRun(t *testing.T) {
 			if (err != nil) != tt.wantErr {
 				t.Fatalf("Run() error = %v, wantErr %v", err, tt.wantErr)
 			}
+			if tt.wantCode != "" && !appErrors.IsCode(err, tt.wantCode) {
+				t.Fatalf("expected %s error, got %v", tt.wantCode, err)
+			}
 
 			if !tt.wantErr && tt.wantOutput {
 				if buf.Len() == 0 {
@@ -66,3 +106,112 @@ func TestConsoleRunnerRun(t *testing.T) {
 		})
 	}
 }
+
+func TestConsoleRunnerRunAll(t *testing.T) {
+	ctx := context.Background()
+	child, err := dream.NewDefaultFootballerDream()
+	if err != nil {
+		t.Fatalf("failed to create default footballer dream: %v", err)
+	}
+	graph, err := formatter.NewGraphFormatter(formatter.GraphDOT)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	csv, err := formatter.NewCSVFormatter()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		formatter ports.FormatterPort
+		out       io.Writer
+		token     string
+		wantCode  appErrors.Code
+	}{
+		{name: "graph draws a dream node per member", formatter: graph, token: `[label="Футболист\nПолевой игрок"`},
+		{name: "csv streams a row per member", formatter: csv, token: "footballer,Футболист,"},
+		{name: "single-result formatter", formatter: formatter.NewTextFormatter(), wantCode: appErrors.CodeValidation},
+		{name: "batch write failure", formatter: graph, out: failingWriter{}, wantCode: appErrors.CodeIO},
+		{name: "batch stream write failure", formatter: csv, out: failingWriter{}, wantCode: appErrors.CodeIO},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr, err := transformer.NewSimpleTransformer(dream.RoleDeveloper)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var buf bytes.Buffer
+			var out io.Writer = &buf
+			if tt.out != nil {
+				out = tt.out
+			}
+			r, err := runner.NewConsoleRunner(transform.NewUseCase(tr), presenter.NewConsolePresenter(), tt.formatter, out)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			err = r.RunAll(ctx, []dream.ChildhoodDream{child, child})
+			if tt.wantCode != "" {
+				if !appErrors.IsCode(err, tt.wantCode) {
+					t.Fatalf("expected %s error, got %v", tt.wantCode, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RunAll() error = %v", err)
+			}
+			if got := strings.Count(buf.String(), tt.token); got != 2 {
+				t.Errorf("count of %q = %d, want 2:\n%s", tt.token, got, buf.String())
+			}
+		})
+	}
+}
+
+func TestConsoleRunnerRunMerged(t *testing.T) {
+	ctx := context.Background()
+	child, err := dream.NewDefaultFootballerDream()
+	if err != nil {
+		t.Fatalf("failed to create default footballer dream: %v", err)
+	}
+	tr, err := transformer.NewSimpleTransformer(dream.RoleDeveloper)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		opts     []runner.ConsoleRunnerOption
+		strategy merge.Strategy
+		wantCode appErrors.Code
+	}{
+		{name: "sources are listed", opts: []runner.ConsoleRunnerOption{runner.WithMerger(merge.NewUseCase(tr))}, strategy: merge.StrategyMax},
+		{name: "merger not configured", strategy: merge.StrategyMax, wantCode: appErrors.CodeInternal},
+		{name: "unknown strategy", opts: []runner.ConsoleRunnerOption{runner.WithMerger(merge.NewUseCase(tr))}, strategy: "random", wantCode: appErrors.CodeDomainFailure},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			r, err := runner.NewConsoleRunner(transform.NewUseCase(tr), presenter.NewConsolePresenter(), formatter.NewMarkdownFormatter(), &buf, tt.opts...)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			err = r.RunMerged(ctx, tt.strategy, []dream.ChildhoodDream{child, child})
+			if tt.wantCode != "" {
+				if !appErrors.IsCode(err, tt.wantCode) {
+					t.Fatalf("expected %s error, got %v", tt.wantCode, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RunMerged() error = %v", err)
+			}
+			if !strings.Contains(buf.String(), "Футболист + Футболист") {
+				t.Errorf("expected the merged dream in output:\n%s", buf.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/xeniasokk/field-switcher/internal/domain/dream"
+	"github.com/xeniasokk/field-switcher/internal/ports"
+	appErrors "github.com/xeniasokk/field-switcher/pkg/errors"
+)
+
+var _ ports.IdentityStore = (*MemoryIdentityStore)(nil)
+
+type MemoryIdentityStore struct {
+	mu       sync.RWMutex
+	versions map[string][]dream.Version
+}
+
+func NewMemoryIdentityStore() *MemoryIdentityStore {
+	return &MemoryIdentityStore{
+		versions: make(map[string][]dream.Version),
+	}
+}
+
+func (s *MemoryIdentityStore) Append(ctx context.Context, v dream.Version) error {
+	_ = ctx
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	history := s.versions[v.PersonID()]
+	var head dream.VersionID
+	if len(history) > 0 {
+		head = history[len(history)-1].ID()
+	}
+	if v.Parent() != head {
+		return appErrors.New(appErrors.CodeConflict,
+			fmt.Sprintf("version %s is based on %q, but head of %s is %q", v.ID(), v.Parent(), v.PersonID(), head),
+		)
+	}
+	for _, existing := range history {
+		if existing.ID() == v.ID() {
+			return appErrors.New(appErrors.CodeConflict, fmt.Sprintf("version %s already exists", v.ID()))
+		}
+	}
+
+	s.versions[v.PersonID()] = append(history, v)
+	return nil
+}
+
+func (s *MemoryIdentityStore) List(ctx context.Context, personID string) ([]dream.Version, error) {
+	_ = ctx
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return slices.Clone(s.versions[personID]), nil
+}
+
+func (s *MemoryIdentityStore) Get(ctx context.Context, personID string, id dream.VersionID) (dream.Version, error) {
+	_ = ctx
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, v := range s.versions[personID] {
+		if v.ID() == id {
+			return v, nil
+		}
+	}
+	return dream.Version{}, appErrors.New(appErrors.CodeNotFound,
+		fmt.Sprintf("version %s not found for %s", id, personID),
+	)
+}
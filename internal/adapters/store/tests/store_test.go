@@ -0,0 +1,67 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/xeniasokk/field-switcher/internal/adapters/store"
+	"github.com/xeniasokk/field-switcher/internal/domain/dream"
+	"github.com/xeniasokk/field-switcher/pkg/errors"
+)
+
+func TestMemoryIdentityStoreAppend(t *testing.T) {
+	ctx := context.Background()
+	f, _ := dream.NewField("Dev", "Team")
+	a, _ := dream.NewAdult("Role", "Desc", f, nil, nil, "")
+
+	newVersion := func(id, parent dream.VersionID) dream.Version {
+		v, err := dream.NewVersion(id, "igor", parent, a, "msg", time.Now())
+		if err != nil {
+			t.Fatalf("failed to create version: %v", err)
+		}
+		return v
+	}
+
+	tests := []struct {
+		name     string
+		versions []dream.Version
+		wantCode errors.Code
+	}{
+		{
+			name:     "linear history",
+			versions: []dream.Version{newVersion("v1", ""), newVersion("v2", "v1")},
+		},
+		{
+			name:     "stale parent conflicts",
+			versions: []dream.Version{newVersion("v1", ""), newVersion("v2", "v1"), newVersion("v3", "v1")},
+			wantCode: errors.CodeConflict,
+		},
+		{
+			name:     "duplicate id conflicts",
+			versions: []dream.Version{newVersion("v1", ""), newVersion("v1", "v1")},
+			wantCode: errors.CodeConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := store.NewMemoryIdentityStore()
+			var err error
+			for _, v := range tt.versions {
+				if err = s.Append(ctx, v); err != nil {
+					break
+				}
+			}
+			if tt.wantCode == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if !errors.IsCode(err, tt.wantCode) {
+				t.Fatalf("expected code %v, got %v", tt.wantCode, errors.CodeOf(err))
+			}
+		})
+	}
+}
@@ -2,21 +2,36 @@ package app
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"time"
 
 	"github.com/xeniasokk/field-switcher/internal/adapters/formatter"
 	"github.com/xeniasokk/field-switcher/internal/adapters/presenter"
 	"github.com/xeniasokk/field-switcher/internal/adapters/runner"
 	"github.com/xeniasokk/field-switcher/internal/adapters/transformer"
+	"github.com/xeniasokk/field-switcher/internal/application/usecase/merge"
 	"github.com/xeniasokk/field-switcher/internal/application/usecase/transform"
+	"github.com/xeniasokk/field-switcher/internal/application/validation"
 	"github.com/xeniasokk/field-switcher/internal/domain/dream"
+	"github.com/xeniasokk/field-switcher/internal/ports"
 	appErrors "github.com/xeniasokk/field-switcher/pkg/errors"
+	"github.com/xeniasokk/field-switcher/pkg/eventbus"
 	"github.com/xeniasokk/field-switcher/pkg/lifecycle"
 )
 
+var modeCSVColumns = map[presenter.Mode][]formatter.CSVColumn{
+	presenter.ModeCompact:   {formatter.ColumnTitle},
+	presenter.ModeExecutive: {formatter.ColumnRoleTitle, formatter.ColumnStack, formatter.ColumnTraits},
+}
+
 type app struct {
-	runner *runner.ConsoleRunner
-	dream  dream.ChildhoodDream
+	runner   *runner.ConsoleRunner
+	dream    dream.ChildhoodDream
+	team     []dream.ChildhoodDream
+	merged   []dream.ChildhoodDream
+	strategy merge.Strategy
+	shutdown *lifecycle.ShutdownSequence
 }
 
 func NewApp() (lifecycle.App, error) {
@@ -24,19 +39,27 @@ func NewApp() (lifecycle.App, error) {
 }
 
 func NewAppWithConfig(cfg Config) (lifecycle.App, error) {
+	if cfg.TeamFile != "" && cfg.MergeFile != "" {
+		return nil, appErrors.NewValidationError("team file and merge file cannot be used together")
+	}
+
 	tr, err := transformer.NewSimpleTransformer(cfg.TargetRole)
 	if err != nil {
 		return nil, appErrors.Wrap(err, appErrors.CodeInternal, "create transformer")
 	}
 
-	uc := transform.NewUseCase(tr)
-
-	p := presenter.NewConsolePresenter()
-	f := formatter.NewTextFormatter()
+	v, err := newValidator(cfg)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.CodeInternal, "create validator")
+	}
 
-	r, err := runner.NewConsoleRunner(uc, p, f, os.Stdout)
+	p, err := newPresenter(cfg)
 	if err != nil {
-		return nil, appErrors.Wrap(err, appErrors.CodeInternal, "create console runner")
+		return nil, appErrors.Wrap(err, appErrors.CodeInternal, "create presenter")
+	}
+	f, err := newFormatter(cfg)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.CodeInternal, "create formatter")
 	}
 
 	defaultDream, err := dream.NewDefaultFootballerDream()
@@ -44,17 +67,182 @@ func NewAppWithConfig(cfg Config) (lifecycle.App, error) {
 		return nil, appErrors.Wrap(err, appErrors.CodeInternal, "create default footballer dream")
 	}
 
+	var team []dream.ChildhoodDream
+	if cfg.TeamFile != "" {
+		team, err = LoadTeamFile(cfg.TeamFile)
+		if err != nil {
+			return nil, appErrors.Wrap(err, appErrors.CodeOf(err), "load team")
+		}
+	}
+	var merged []dream.ChildhoodDream
+	if cfg.MergeFile != "" {
+		merged, err = LoadTeamFile(cfg.MergeFile)
+		if err != nil {
+			return nil, appErrors.Wrap(err, appErrors.CodeOf(err), "load dreams to merge")
+		}
+	}
+
+	// шина запускает горутины, поэтому создаётся последней
+	shutdown := lifecycle.NewShutdownSequence()
+	bus := eventbus.New(eventbus.WithQueueSize(cfg.EventQueueSize))
+	shutdown.Add("event bus", bus)
+
+	uc := transform.NewUseCase(tr, transform.WithValidator(v), transform.WithPublisher(bus))
+	mergeUC := merge.NewUseCase(tr, merge.WithValidator(v))
+	r, err := runner.NewConsoleRunner(uc, p, f, os.Stdout, runner.WithMerger(mergeUC))
+	if err != nil {
+		_ = bus.Shutdown(context.Background())
+		return nil, appErrors.Wrap(err, appErrors.CodeInternal, "create console runner")
+	}
+
 	return &app{
-		runner: r,
-		dream:  defaultDream,
+		runner:   r,
+		dream:    defaultDream,
+		team:     team,
+		merged:   merged,
+		strategy: cfg.MergeStrategy,
+		shutdown: shutdown,
 	}, nil
 }
 
 func (a *app) Run(ctx context.Context) error {
+	if a.team != nil {
+		return a.runner.RunAll(ctx, a.team)
+	}
+	if a.merged != nil {
+		return a.runner.RunMerged(ctx, a.strategy, a.merged)
+	}
 	return a.runner.Run(ctx, a.dream)
 }
 
 func (a *app) Shutdown(ctx context.Context) error {
-	_ = ctx
-	return nil
+	return a.shutdown.Shutdown(ctx)
+}
+
+func newValidator(cfg Config) (*validation.Validator, error) {
+	policies := cfg.Validation
+	if cfg.ValidationFile != "" {
+		loaded, err := validation.LoadConfigFile(cfg.ValidationFile)
+		if err != nil {
+			return nil, err
+		}
+		policies = loaded
+	}
+	return validation.NewValidatorFromConfig(policies)
+}
+
+func newPresenter(cfg Config) (ports.PresenterPort, error) {
+	rules := cfg.Highlights
+	if cfg.HighlightFile != "" {
+		loaded, err := presenter.LoadHighlightRulesFile(cfg.HighlightFile)
+		if err != nil {
+			return nil, err
+		}
+		rules = loaded
+	}
+	var opts []presenter.ConsolePresenterOption
+	if rules != nil {
+		opts = append(opts, presenter.WithHighlightRules(rules))
+	}
+	return presenter.NewPresenter(cfg.Mode, opts...)
+}
+
+func newFormatter(cfg Config) (ports.FormatterPort, error) {
+	registry := cfg.Formatters
+	if registry == nil {
+		registry = formatter.DefaultRegistry()
+	}
+
+	reg, err := resolveFormat(cfg, registry)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.TeamFile != "" && !reg.Capabilities.Batch {
+		return nil, appErrors.NewValidationError(
+			fmt.Sprintf("format %q cannot render a team, use one of the batch formats", reg.Name),
+		)
+	}
+	if reg.Capabilities.Binary && formatter.IsTerminal(os.Stdout) {
+		return nil, appErrors.NewValidationError(
+			fmt.Sprintf("format %q is binary, redirect the output to a file", reg.Name),
+		)
+	}
+
+	theme, err := loadTheme(cfg)
+	if err != nil {
+		return nil, err
+	}
+	catalog, err := formatter.NewCatalog(cfg.Locale)
+	if err != nil {
+		return nil, err
+	}
+	settings := formatter.Settings{
+		Theme:            theme,
+		Catalog:          catalog,
+		Boxes:            cfg.Boxes,
+		Layout:           cfg.Layout,
+		TemplateFile:     cfg.TemplateFile,
+		StrictTemplate:   cfg.StrictTemplate,
+		CSVColumns:       csvColumns(cfg),
+		CSVListSeparator: cfg.CSVListSeparator,
+		FrontMatter:      cfg.FrontMatter,
+		IssuedAt:         issuedAt(cfg),
+	}
+	if reg.Capabilities.Color {
+		settings.Color = formatter.ColorEnabled(cfg.Color, os.Stdout)
+	}
+	if reg.Capabilities.Width {
+		settings.Width = cfg.Width
+		if settings.Width == 0 {
+			settings.Width = formatter.DetectWidth(os.Stdout)
+		}
+	}
+
+	f, err := registry.New(reg.Name, settings)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.TeamFile != "" {
+		// Stream скрыл бы BatchFormatterPort, а пакетный вывод runner выбирает сам
+		return f, nil
+	}
+	return formatter.Stream(f), nil
+}
+
+func csvColumns(cfg Config) []formatter.CSVColumn {
+	if len(cfg.CSVColumns) > 0 {
+		return cfg.CSVColumns
+	}
+	return modeCSVColumns[cfg.Mode]
+}
+
+func resolveFormat(cfg Config, registry *formatter.Registry) (formatter.Registration, error) {
+	switch {
+	case cfg.Format != "":
+		return registry.Lookup(cfg.Format)
+	case cfg.Accessible:
+		return registry.Lookup(formatter.FormatAccessible)
+	case cfg.TemplateFile != "":
+		return registry.Lookup(formatter.FormatTemplate)
+	case cfg.Accept != "":
+		return registry.Negotiate(cfg.Accept)
+	default:
+		return registry.Lookup(formatter.FormatText)
+	}
+}
+
+func loadTheme(cfg Config) (formatter.Theme, error) {
+	if cfg.ThemeFile != "" {
+		return formatter.LoadThemeFile(cfg.ThemeFile)
+	}
+	return formatter.BuiltinTheme(cfg.Theme)
+}
+
+// Полночь UTC: повторный запуск в тот же день даёт тот же pdf.
+func issuedAt(cfg Config) time.Time {
+	if !cfg.IssuedAt.IsZero() {
+		return cfg.IssuedAt
+	}
+	y, m, d := time.Now().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
 }
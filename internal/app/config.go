@@ -1,13 +1,72 @@
 package app
 
-import "github.com/xeniasokk/field-switcher/internal/domain/dream"
+import (
+	"time"
+
+	"github.com/xeniasokk/field-switcher/internal/adapters/formatter"
+	"github.com/xeniasokk/field-switcher/internal/adapters/presenter"
+	"github.com/xeniasokk/field-switcher/internal/application/usecase/merge"
+	"github.com/xeniasokk/field-switcher/internal/application/validation"
+	"github.com/xeniasokk/field-switcher/internal/domain/dream"
+)
 
 type Config struct {
+	// Пусто — выбор по Accept, -accessible, -template или text
+	Format string
+	Accept string
+	// nil — formatter.DefaultRegistry()
+	Formatters *formatter.Registry
+
 	TargetRole dream.Role
+	Validation validation.Config
+	// JSON-файл с политиками валидации, заменяет Validation
+	ValidationFile string
+	// Сколько асинхронных событий ждёт доставки; при полной очереди публикация ждёт места
+	EventQueueSize int
+	Locale         formatter.Locale
+	Theme          string
+	ThemeFile      string
+	Color          formatter.ColorMode
+	// 0 — ширина берётся из терминала или COLUMNS
+	Width  int
+	Boxes  bool
+	Layout formatter.TextLayout
+	// Вывод для экранных дикторов
+	Accessible bool
+	// text/template вместо TextFormatter
+	TemplateFile   string
+	StrictTemplate bool
+	// YAML-шапка для markdown
+	FrontMatter bool
+	// Дата выдачи pdf-сертификата; нулевая — сегодняшняя
+	IssuedAt time.Time
+	// Режим представления: compact, detailed или executive; пусто — presenter.DefaultMode
+	Mode presenter.Mode
+	// nil — presenter.DefaultHighlightRules(), пустой список — ничего
+	Highlights []presenter.HighlightRule
+	// JSON-файл с правилами подсветки, заменяет Highlights
+	HighlightFile string
+	// Колонки csv и tsv; пусто — набор по режиму, для detailed formatter.DefaultCSVColumns
+	CSVColumns []formatter.CSVColumn
+	// Разделитель многозначных полей csv и tsv; пусто — "; "
+	CSVListSeparator string
+	// JSON или YAML; нужен формат с пакетным выводом (csv, tsv, dot, mermaid)
+	TeamFile string
+	// Файл мечт в формате TeamFile, которые сводятся в одну взрослую роль
+	MergeFile     string
+	MergeStrategy merge.Strategy
 }
 
 func DefaultConfig() Config {
 	return Config{
-		TargetRole: dream.RoleTeamLead,
+		TargetRole:     dream.RoleTeamLead,
+		Validation:     validation.DefaultConfig(),
+		EventQueueSize: 64,
+		Locale:         formatter.DefaultLocale,
+		Theme:          formatter.ThemeDark,
+		Color:          formatter.ColorAuto,
+		Layout:         formatter.LayoutStacked,
+		Mode:           presenter.DefaultMode,
+		MergeStrategy:  merge.StrategyMax,
 	}
 }
@@ -0,0 +1,117 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/xeniasokk/field-switcher/internal/adapters/formatter"
+	"github.com/xeniasokk/field-switcher/internal/domain/dream"
+	appErrors "github.com/xeniasokk/field-switcher/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// Элементы записаны как поле childhood в JSON-выводе.
+func ParseTeam(data []byte) ([]dream.ChildhoodDream, error) {
+	var members []formatter.JSONChildhood
+	if err := json.Unmarshal(data, &members); err != nil {
+		return nil, appErrors.Wrap(err, appErrors.CodeValidation, "parse team")
+	}
+	return newTeam(members)
+}
+
+// ParseTeamYAML принимает список, как ParseTeam, или поток документов YAMLFormatter.
+func ParseTeamYAML(data []byte) ([]dream.ChildhoodDream, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	var members []formatter.JSONChildhood
+	for {
+		var node any
+		err := dec.Decode(&node)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, appErrors.Wrap(err, appErrors.CodeValidation, "parse team")
+		}
+		found, err := yamlMembers(node)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, found...)
+	}
+	return newTeam(members)
+}
+
+func LoadTeamFile(path string) ([]dream.ChildhoodDream, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.CodeIO, fmt.Sprintf("read team file %s", path))
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return ParseTeamYAML(data)
+	default:
+		return ParseTeam(data)
+	}
+}
+
+func yamlMembers(node any) ([]formatter.JSONChildhood, error) {
+	if doc, ok := node.(map[string]any); ok {
+		child, ok := doc["childhood"]
+		if !ok {
+			return nil, appErrors.NewValidationError("parse team: YAML document has no childhood")
+		}
+		node = []any{child}
+	}
+	data, err := json.Marshal(node)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.CodeValidation, "parse team")
+	}
+	var members []formatter.JSONChildhood
+	if err := json.Unmarshal(data, &members); err != nil {
+		return nil, appErrors.Wrap(err, appErrors.CodeValidation, "parse team")
+	}
+	return members, nil
+}
+
+func newTeam(members []formatter.JSONChildhood) ([]dream.ChildhoodDream, error) {
+	if len(members) == 0 {
+		return nil, appErrors.NewValidationError("team has no childhood dreams")
+	}
+
+	team := make([]dream.ChildhoodDream, 0, len(members))
+	for i, m := range members {
+		d, err := teamDream(m)
+		if err != nil {
+			return nil, appErrors.Wrap(err, appErrors.CodeValidation, fmt.Sprintf("team member %d", i+1))
+		}
+		team = append(team, d)
+	}
+	return team, nil
+}
+
+func teamDream(m formatter.JSONChildhood) (dream.ChildhoodDream, error) {
+	field, err := dream.NewField(m.Field.Name, m.Field.Environment)
+	if err != nil {
+		return dream.ChildhoodDream{}, err
+	}
+	qualities := make([]dream.Quality, 0, len(m.Qualities))
+	for _, q := range m.Qualities {
+		var quality dream.Quality
+		if q.Intensity != 0 {
+			quality, err = dream.NewQualityWithIntensity(q.Name, q.Description, q.Intensity)
+		} else {
+			quality, err = dream.NewQuality(q.Name, q.Description)
+		}
+		if err != nil {
+			return dream.ChildhoodDream{}, err
+		}
+		qualities = append(qualities, quality)
+	}
+	return dream.NewChildhoodDream(dream.Type(m.Type), m.DisplayName, m.DesiredRole, field, qualities)
+}
@@ -0,0 +1,141 @@
+package tests
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xeniasokk/field-switcher/internal/adapters/formatter"
+	"github.com/xeniasokk/field-switcher/internal/adapters/presenter"
+	"github.com/xeniasokk/field-switcher/internal/app"
+)
+
+func TestNewAppWithCSVColumns(t *testing.T) {
+	tests := []struct {
+		name    string
+		columns []formatter.CSVColumn
+		wantErr bool
+	}{
+		{name: "known columns", columns: []formatter.CSVColumn{formatter.ColumnRoleTitle, formatter.ColumnStack}},
+		// неизвестная колонка доходит до фабрики csv и отвергается там
+		{name: "unknown column", columns: []formatter.CSVColumn{"salary"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := app.DefaultConfig()
+			cfg.Format = formatter.FormatCSV
+			cfg.CSVColumns = tt.columns
+			cfg.CSVListSeparator = " / "
+			_, err := app.NewAppWithConfig(cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewAppWithConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// Без явных колонок csv выводит то, что есть в режиме: сводку в compact и роль в executive.
+func TestNewAppCSVColumnsFollowMode(t *testing.T) {
+	tests := []struct {
+		mode       presenter.Mode
+		wantHeader string
+		wantRow    string
+	}{
+		{mode: presenter.ModeCompact, wantHeader: "title", wantRow: `"Тимлид — Капитан команды`},
+		{mode: presenter.ModeDetailed, wantHeader: "type,display_name,role_title,stack,traits,note", wantRow: "footballer,Футболист,Тимлид,"},
+		{mode: presenter.ModeExecutive, wantHeader: "role_title,stack,traits", wantRow: "Тимлид,System Design;"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			cfg := app.DefaultConfig()
+			cfg.Format = formatter.FormatCSV
+			cfg.Mode = tt.mode
+
+			out := captureStdout(t, func() {
+				a, err := app.NewAppWithConfig(cfg)
+				if err != nil {
+					t.Fatalf("NewAppWithConfig() error = %v", err)
+				}
+				defer a.Shutdown(context.Background())
+				if err := a.Run(context.Background()); err != nil {
+					t.Fatalf("Run() error = %v", err)
+				}
+			})
+
+			header, row, _ := strings.Cut(out, "\n")
+			if header != tt.wantHeader {
+				t.Errorf("header = %q, want %q", header, tt.wantHeader)
+			}
+			if !strings.HasPrefix(row, tt.wantRow) {
+				t.Errorf("row = %q, want prefix %q", row, tt.wantRow)
+			}
+		})
+	}
+}
+
+func TestNewAppPDFUsesIssueDate(t *testing.T) {
+	cfg := app.DefaultConfig()
+	cfg.Format = formatter.FormatPDF
+	cfg.IssuedAt = time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+
+	run := func() string {
+		return captureStdout(t, func() {
+			a, err := app.NewAppWithConfig(cfg)
+			if err != nil {
+				t.Fatalf("NewAppWithConfig() error = %v", err)
+			}
+			defer a.Shutdown(context.Background())
+			if err := a.Run(context.Background()); err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+		})
+	}
+
+	out := run()
+	if !strings.Contains(out, "/CreationDate (D:20260901") {
+		t.Fatalf("pdf metadata does not carry the issue date")
+	}
+	if run() != out {
+		t.Fatalf("pdf output is not reproducible with a fixed issue date")
+	}
+}
+
+// captureStdout подменяет os.Stdout: приложение пишет результат прямо в него.
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	f, err := os.Create(filepath.Join(t.TempDir(), "stdout"))
+	if err != nil {
+		t.Fatalf("failed to create stdout file: %v", err)
+	}
+	defer f.Close()
+
+	stdout := os.Stdout
+	os.Stdout = f
+	defer func() { os.Stdout = stdout }()
+	fn()
+
+	data, err := os.ReadFile(f.Name())
+	if err != nil {
+		t.Fatalf("failed to read stdout file: %v", err)
+	}
+	return string(data)
+}
+
+func TestNewAppFailureStartsNoGoroutines(t *testing.T) {
+	cfg := app.DefaultConfig()
+	cfg.Format = "unknown"
+
+	before := runtime.NumGoroutine()
+	for i := 0; i < 20; i++ {
+		if _, err := app.NewAppWithConfig(cfg); err == nil {
+			t.Fatalf("expected unknown format to fail")
+		}
+	}
+	if after := runtime.NumGoroutine(); after > before {
+		t.Fatalf("goroutines grew from %d to %d after failed construction", before, after)
+	}
+}
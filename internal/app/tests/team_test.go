@@ -0,0 +1,222 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/xeniasokk/field-switcher/internal/adapters/formatter"
+	"github.com/xeniasokk/field-switcher/internal/adapters/presenter"
+	"github.com/xeniasokk/field-switcher/internal/app"
+	"github.com/xeniasokk/field-switcher/internal/application/usecase/transform"
+	"github.com/xeniasokk/field-switcher/internal/domain/dream"
+	appErrors "github.com/xeniasokk/field-switcher/pkg/errors"
+)
+
+const teamJSON = `[
+  {"type": "footballer", "display_name": "Футболист", "desired_role": "Полевой игрок",
+   "field": {"name": "Поле", "environment": "Стадион"},
+   "qualities": [{"name": "Упорство", "description": "не сдаваться", "intensity": 5}]},
+  {"type": "footballer", "display_name": "Вратарь", "desired_role": "Голкипер",
+   "field": {"name": "Доска"},
+   "qualities": [{"name": "Расчёт", "description": "думать наперёд"}]}
+]`
+
+func TestParseTeam(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		want     []string
+		wantCode appErrors.Code
+	}{
+		{name: "members in file order", data: teamJSON, want: []string{"Футболист", "Вратарь"}},
+		{name: "empty team", data: `[]`, wantCode: appErrors.CodeValidation},
+		{name: "malformed json", data: `{`, wantCode: appErrors.CodeValidation},
+		{
+			name:     "invalid member",
+			data:     `[{"display_name": "Без поля", "desired_role": "r", "qualities": [{"name": "q"}]}]`,
+			wantCode: appErrors.CodeValidation,
+		},
+		{
+			name:     "intensity out of range",
+			data:     `[{"display_name": "d", "desired_role": "r", "field": {"name": "f"}, "qualities": [{"name": "q", "intensity": 11}]}]`,
+			wantCode: appErrors.CodeValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			team, err := app.ParseTeam([]byte(tt.data))
+			if tt.wantCode != "" {
+				if !appErrors.IsCode(err, tt.wantCode) {
+					t.Fatalf("expected %s error, got %v", tt.wantCode, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseTeam() error = %v", err)
+			}
+			if len(team) != len(tt.want) {
+				t.Fatalf("got %d members, want %d", len(team), len(tt.want))
+			}
+			for i, name := range tt.want {
+				if team[i].DisplayName() != name {
+					t.Errorf("member %d = %q, want %q", i, team[i].DisplayName(), name)
+				}
+			}
+			if q := team[0].Qualities()[0]; q.Intensity() != 5 {
+				t.Errorf("intensity = %d, want 5", q.Intensity())
+			}
+		})
+	}
+}
+
+func TestNewAppWithTeamNeedsBatchFormat(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "team.json")
+	if err := os.WriteFile(path, []byte(teamJSON), 0o600); err != nil {
+		t.Fatalf("write team file: %v", err)
+	}
+
+	tests := []struct {
+		format  string
+		wantErr bool
+	}{
+		{format: formatter.FormatDOT},
+		{format: formatter.FormatMermaid},
+		{format: formatter.FormatCSV},
+		{format: formatter.FormatJSON, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			cfg := app.DefaultConfig()
+			cfg.Format = tt.format
+			cfg.TeamFile = path
+			_, err := app.NewAppWithConfig(cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewAppWithConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseTeamYAMLRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	long := strings.Repeat("держать удар до последней минуты, ", 6) + "\nи вторая строка"
+	want, err := app.ParseTeam([]byte(strings.Replace(teamJSON, "не сдаваться", strings.ReplaceAll(long, "\n", `\n`), 1)))
+	if err != nil {
+		t.Fatalf("ParseTeam() error = %v", err)
+	}
+	f, _ := dream.NewField("Dev", "Team")
+	adult, _ := dream.NewAdult("Role", "Desc", f, []string{"Go"}, want[0].Qualities(), "")
+
+	var docs []string
+	for _, child := range want {
+		vm, err := presenter.NewConsolePresenter().Present(ctx, transform.NewOutput(child, adult))
+		if err != nil {
+			t.Fatalf("Present() error = %v", err)
+		}
+		out, err := formatter.NewYAMLFormatter().Format(ctx, vm)
+		if err != nil {
+			t.Fatalf("Format() error = %v", err)
+		}
+		docs = append(docs, out)
+	}
+
+	got, err := app.ParseTeamYAML([]byte(strings.Join(docs, "---\n")))
+	if err != nil {
+		t.Fatalf("ParseTeamYAML() error = %v\n%s", err, strings.Join(docs, "---\n"))
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("round trip changed the team:\ngot  %+v\nwant %+v", got, want)
+	}
+}
+
+func TestParseTeamYAML(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		want     []string
+		wantCode appErrors.Code
+	}{
+		{
+			name: "list of childhood dreams",
+			data: `- display_name: Футболист
+  desired_role: Полевой игрок
+  field: {name: Поле}
+  qualities: [{name: Упорство, intensity: 5}]
+`,
+			want: []string{"Футболист"},
+		},
+		{name: "document without childhood", data: "schema_version: \"1\"\n", wantCode: appErrors.CodeValidation},
+		{name: "empty file", data: "", wantCode: appErrors.CodeValidation},
+		{name: "malformed yaml", data: "- [", wantCode: appErrors.CodeValidation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			team, err := app.ParseTeamYAML([]byte(tt.data))
+			if tt.wantCode != "" {
+				if !appErrors.IsCode(err, tt.wantCode) {
+					t.Fatalf("expected %s error, got %v", tt.wantCode, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseTeamYAML() error = %v", err)
+			}
+			var names []string
+			for _, d := range team {
+				names = append(names, d.DisplayName())
+			}
+			if !reflect.DeepEqual(names, tt.want) {
+				t.Fatalf("members = %v, want %v", names, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewAppMergeFileShowsSources(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "dreams.json")
+	if err := os.WriteFile(path, []byte(teamJSON), 0o600); err != nil {
+		t.Fatalf("write merge file: %v", err)
+	}
+
+	cfg := app.DefaultConfig()
+	cfg.Format = formatter.FormatJSON
+	cfg.MergeFile = path
+	out := captureStdout(t, func() {
+		a, err := app.NewAppWithConfig(cfg)
+		if err != nil {
+			t.Fatalf("NewAppWithConfig() error = %v", err)
+		}
+		defer a.Shutdown(context.Background())
+		if err := a.Run(context.Background()); err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+	})
+
+	var doc formatter.JSONDocument
+	if err := json.Unmarshal([]byte(out), &doc); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out)
+	}
+	if doc.Childhood == nil || doc.Childhood.DisplayName != "Футболист + Вратарь" {
+		t.Fatalf("childhood = %+v, want the merged dream", doc.Childhood)
+	}
+	var sources []string
+	for _, s := range doc.Sources {
+		sources = append(sources, s.DisplayName)
+	}
+	if !reflect.DeepEqual(sources, []string{"Футболист", "Вратарь"}) {
+		t.Fatalf("sources = %v, want both dreams", sources)
+	}
+
+	cfg.TeamFile = path
+	if _, err := app.NewAppWithConfig(cfg); !appErrors.IsCode(err, appErrors.CodeValidation) {
+		t.Fatalf("expected validation error for team and merge files together, got %v", err)
+	}
+}
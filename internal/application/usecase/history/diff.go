@@ -0,0 +1,95 @@
+package history
+
+import (
+	"slices"
+
+	"github.com/xeniasokk/field-switcher/internal/domain/dream"
+)
+
+type FieldChange struct {
+	Field string
+	From  string
+	To    string
+}
+
+type Diff struct {
+	From          dream.VersionID
+	To            dream.VersionID
+	Changes       []FieldChange
+	StackAdded    []string
+	StackRemoved  []string
+	TraitsAdded   []string
+	TraitsRemoved []string
+	TraitsChanged []FieldChange
+}
+
+func (d Diff) Empty() bool {
+	return len(d.Changes) == 0 &&
+		len(d.StackAdded) == 0 && len(d.StackRemoved) == 0 &&
+		len(d.TraitsAdded) == 0 && len(d.TraitsRemoved) == 0 &&
+		len(d.TraitsChanged) == 0
+}
+
+func diffVersions(from, to dream.Version) Diff {
+	a, b := from.Adult(), to.Adult()
+	d := Diff{From: from.ID(), To: to.ID()}
+
+	fields := []FieldChange{
+		{Field: "role_title", From: a.RoleTitle(), To: b.RoleTitle()},
+		{Field: "role_description", From: a.RoleDescription(), To: b.RoleDescription()},
+		{Field: "field_name", From: a.Field().Name(), To: b.Field().Name()},
+		{Field: "field_environment", From: a.Field().Environment(), To: b.Field().Environment()},
+		{Field: "comment", From: a.Comment(), To: b.Comment()},
+	}
+	for _, c := range fields {
+		if c.From != c.To {
+			d.Changes = append(d.Changes, c)
+		}
+	}
+
+	d.StackAdded, d.StackRemoved = diffStrings(a.Stack(), b.Stack())
+
+	fromTraits := traitsByName(a.Traits())
+	toTraits := traitsByName(b.Traits())
+	for _, q := range b.Traits() {
+		prev, ok := fromTraits[q.Name()]
+		if !ok {
+			d.TraitsAdded = append(d.TraitsAdded, q.Name())
+			continue
+		}
+		if prev.Description() != q.Description() {
+			d.TraitsChanged = append(d.TraitsChanged, FieldChange{
+				Field: q.Name(), From: prev.Description(), To: q.Description(),
+			})
+		}
+	}
+	for _, q := range a.Traits() {
+		if _, ok := toTraits[q.Name()]; !ok {
+			d.TraitsRemoved = append(d.TraitsRemoved, q.Name())
+		}
+	}
+
+	return d
+}
+
+func diffStrings(from, to []string) (added, removed []string) {
+	for _, s := range to {
+		if !slices.Contains(from, s) {
+			added = append(added, s)
+		}
+	}
+	for _, s := range from {
+		if !slices.Contains(to, s) {
+			removed = append(removed, s)
+		}
+	}
+	return added, removed
+}
+
+func traitsByName(traits []dream.Quality) map[string]dream.Quality {
+	m := make(map[string]dream.Quality, len(traits))
+	for _, q := range traits {
+		m[q.Name()] = q
+	}
+	return m
+}
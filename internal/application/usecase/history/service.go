@@ -0,0 +1,115 @@
+package history
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/xeniasokk/field-switcher/internal/domain/dream"
+	"github.com/xeniasokk/field-switcher/internal/ports"
+	"github.com/xeniasokk/field-switcher/pkg/errors"
+)
+
+type Service interface {
+	Commit(ctx context.Context, personID string, adult dream.Adult, message string) (dream.Version, error)
+	List(ctx context.Context, personID string) ([]dream.Version, error)
+	Head(ctx context.Context, personID string) (dream.Version, error)
+	Diff(ctx context.Context, personID string, from, to dream.VersionID) (Diff, error)
+	Rollback(ctx context.Context, personID string, to dream.VersionID, message string) (dream.Version, error)
+}
+
+type service struct {
+	store ports.IdentityStore
+	now   func() time.Time
+}
+
+type Option func(*service)
+
+func WithClock(now func() time.Time) Option {
+	return func(s *service) {
+		s.now = now
+	}
+}
+
+func NewService(store ports.IdentityStore, opts ...Option) Service {
+	s := &service{
+		store: store,
+		now:   time.Now,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *service) Commit(ctx context.Context, personID string, adult dream.Adult, message string) (dream.Version, error) {
+	versions, err := s.store.List(ctx, personID)
+	if err != nil {
+		return dream.Version{}, errors.Wrap(err, errors.CodeIO, "failed to list identity versions")
+	}
+
+	var parent dream.VersionID
+	if len(versions) > 0 {
+		parent = versions[len(versions)-1].ID()
+	}
+
+	v, err := dream.NewVersion(
+		dream.VersionID(fmt.Sprintf("v%d", len(versions)+1)),
+		personID,
+		parent,
+		adult,
+		message,
+		s.now(),
+	)
+	if err != nil {
+		return dream.Version{}, errors.Wrap(err, errors.CodeValidation, "invalid identity version")
+	}
+
+	if err := s.store.Append(ctx, v); err != nil {
+		return dream.Version{}, errors.Wrap(err, errors.CodeOf(err), "failed to store identity version")
+	}
+	return v, nil
+}
+
+func (s *service) List(ctx context.Context, personID string) ([]dream.Version, error) {
+	versions, err := s.store.List(ctx, personID)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeIO, "failed to list identity versions")
+	}
+	return versions, nil
+}
+
+func (s *service) Head(ctx context.Context, personID string) (dream.Version, error) {
+	versions, err := s.List(ctx, personID)
+	if err != nil {
+		return dream.Version{}, err
+	}
+	if len(versions) == 0 {
+		return dream.Version{}, errors.New(errors.CodeNotFound, fmt.Sprintf("no identity versions for %s", personID))
+	}
+	return versions[len(versions)-1], nil
+}
+
+func (s *service) Diff(ctx context.Context, personID string, from, to dream.VersionID) (Diff, error) {
+	a, err := s.store.Get(ctx, personID, from)
+	if err != nil {
+		return Diff{}, errors.Wrap(err, errors.CodeOf(err), "failed to load base version")
+	}
+	b, err := s.store.Get(ctx, personID, to)
+	if err != nil {
+		return Diff{}, errors.Wrap(err, errors.CodeOf(err), "failed to load target version")
+	}
+	return diffVersions(a, b), nil
+}
+
+// Rollback не переписывает историю: содержимое старой версии становится новой головой.
+func (s *service) Rollback(ctx context.Context, personID string, to dream.VersionID, message string) (dream.Version, error) {
+	target, err := s.store.Get(ctx, personID, to)
+	if err != nil {
+		return dream.Version{}, errors.Wrap(err, errors.CodeOf(err), "failed to load rollback target")
+	}
+	if message == "" {
+		message = fmt.Sprintf("rollback to %s", to)
+	}
+	return s.Commit(ctx, personID, target.Adult(), message)
+}
@@ -0,0 +1,133 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/xeniasokk/field-switcher/internal/adapters/store"
+	"github.com/xeniasokk/field-switcher/internal/application/usecase/history"
+	"github.com/xeniasokk/field-switcher/internal/domain/dream"
+	"github.com/xeniasokk/field-switcher/pkg/errors"
+)
+
+func newAdult(t *testing.T, title string, stack []string, traits ...string) dream.Adult {
+	t.Helper()
+	f, _ := dream.NewField("Dev", "Team")
+	qualities := make([]dream.Quality, 0, len(traits))
+	for _, name := range traits {
+		q, _ := dream.NewQuality(name, "desc")
+		qualities = append(qualities, q)
+	}
+	a, err := dream.NewAdult(title, "Desc", f, stack, qualities, "comment")
+	if err != nil {
+		t.Fatalf("failed to create adult: %v", err)
+	}
+	return a
+}
+
+func TestHistoryService(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	svc := history.NewService(store.NewMemoryIdentityStore(), history.WithClock(func() time.Time { return now }))
+
+	v1, err := svc.Commit(ctx, "igor", newAdult(t, "Разработчик", []string{"Go", "Git"}, "Упорство"), "initial")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	v2, err := svc.Commit(ctx, "igor", newAdult(t, "Тимлид", []string{"Go", "CI/CD"}, "Упорство", "Командный дух"), "promotion")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v2.Parent() != v1.ID() {
+		t.Fatalf("expected parent %s, got %s", v1.ID(), v2.Parent())
+	}
+
+	diff, err := svc.Diff(ctx, "igor", v1.ID(), v2.ID())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(diff.Changes) != 1 || diff.Changes[0].Field != "role_title" {
+		t.Fatalf("expected only role title change, got %+v", diff.Changes)
+	}
+	if len(diff.StackAdded) != 1 || diff.StackAdded[0] != "CI/CD" {
+		t.Fatalf("expected CI/CD added, got %v", diff.StackAdded)
+	}
+	if len(diff.StackRemoved) != 1 || diff.StackRemoved[0] != "Git" {
+		t.Fatalf("expected Git removed, got %v", diff.StackRemoved)
+	}
+	if len(diff.TraitsAdded) != 1 || diff.TraitsAdded[0] != "Командный дух" {
+		t.Fatalf("expected trait added, got %v", diff.TraitsAdded)
+	}
+
+	v3, err := svc.Rollback(ctx, "igor", v1.ID(), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v3.Parent() != v2.ID() {
+		t.Fatalf("rollback must append to history, got parent %s", v3.Parent())
+	}
+	if v3.Adult().RoleTitle() != "Разработчик" {
+		t.Fatalf("expected rolled back role, got %s", v3.Adult().RoleTitle())
+	}
+
+	versions, err := svc.List(ctx, "igor")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(versions) != 3 {
+		t.Fatalf("expected 3 versions, got %d", len(versions))
+	}
+
+	back, err := svc.Diff(ctx, "igor", v1.ID(), v3.ID())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !back.Empty() {
+		t.Fatalf("expected no difference after rollback, got %+v", back)
+	}
+}
+
+func TestHistoryServiceErrors(t *testing.T) {
+	ctx := context.Background()
+	svc := history.NewService(store.NewMemoryIdentityStore())
+
+	tests := []struct {
+		name     string
+		call     func() error
+		wantCode errors.Code
+	}{
+		{
+			name: "empty message",
+			call: func() error {
+				_, err := svc.Commit(ctx, "igor", newAdult(t, "Role", nil), "")
+				return err
+			},
+			wantCode: errors.CodeValidation,
+		},
+		{
+			name: "head of unknown person",
+			call: func() error {
+				_, err := svc.Head(ctx, "nobody")
+				return err
+			},
+			wantCode: errors.CodeNotFound,
+		},
+		{
+			name: "rollback to unknown version",
+			call: func() error {
+				_, err := svc.Rollback(ctx, "igor", "v42", "")
+				return err
+			},
+			wantCode: errors.CodeNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); !errors.IsCode(err, tt.wantCode) {
+				t.Fatalf("expected code %v, got %v (%v)", tt.wantCode, errors.CodeOf(err), err)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package merge
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/xeniasokk/field-switcher/internal/application/validation"
+	"github.com/xeniasokk/field-switcher/internal/domain/dream"
+)
+
+type InputModel struct {
+	children []dream.ChildhoodDream
+	strategy Strategy
+}
+
+func NewInput(strategy Strategy, children ...dream.ChildhoodDream) InputModel {
+	return InputModel{
+		children: slices.Clone(children),
+		strategy: strategy,
+	}
+}
+
+func (i InputModel) Children() []dream.ChildhoodDream {
+	return slices.Clone(i.children)
+}
+
+func (i InputModel) Strategy() Strategy {
+	if i.strategy == "" {
+		return StrategyMax
+	}
+	return i.strategy
+}
+
+func (i InputModel) Validate(ctx context.Context) error {
+	return i.violations(ctx).Err()
+}
+
+func (i InputModel) violations(ctx context.Context) validation.Violations {
+	_ = ctx
+	var violations validation.Violations
+	if len(i.children) == 0 {
+		violations = append(violations, validation.Violation{
+			Policy:  validation.PolicyInput,
+			Message: "at least one childhood dream is required to merge",
+		})
+	}
+	for idx, child := range i.children {
+		if child.DisplayName() == "" {
+			violations = append(violations, validation.Violation{
+				Policy:  validation.PolicyInput,
+				Field:   validation.FieldDisplayName,
+				Message: fmt.Sprintf("is required for childhood dream #%d", idx+1),
+			})
+		}
+	}
+	if !i.Strategy().Valid() {
+		violations = append(violations, validation.Violation{
+			Policy:  validation.PolicyInput,
+			Value:   string(i.strategy),
+			Message: fmt.Sprintf("unsupported merge strategy: %s", i.strategy),
+		})
+	}
+	return violations
+}
@@ -0,0 +1,28 @@
+package merge
+
+import (
+	"slices"
+
+	"github.com/xeniasokk/field-switcher/internal/domain/dream"
+	"github.com/xeniasokk/field-switcher/internal/ports"
+)
+
+var _ ports.MultiSourceOutput = OutputModel{}
+
+type OutputModel struct {
+	child   dream.ChildhoodDream
+	sources []dream.ChildhoodDream
+	adult   dream.Adult
+}
+
+func NewOutput(child dream.ChildhoodDream, sources []dream.ChildhoodDream, adult dream.Adult) OutputModel {
+	return OutputModel{
+		child:   child,
+		sources: slices.Clone(sources),
+		adult:   adult,
+	}
+}
+
+func (o OutputModel) Child() dream.ChildhoodDream     { return o.child }
+func (o OutputModel) Sources() []dream.ChildhoodDream { return slices.Clone(o.sources) }
+func (o OutputModel) Adult() dream.Adult              { return o.adult }
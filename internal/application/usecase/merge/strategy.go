@@ -0,0 +1,42 @@
+package merge
+
+import "github.com/xeniasokk/field-switcher/internal/domain/dream"
+
+type Strategy string
+
+const (
+	StrategyMax   Strategy = "max"
+	StrategyFirst Strategy = "first"
+	// StrategyAverage берёт описание из первой мечты
+	StrategyAverage Strategy = "average"
+)
+
+func (s Strategy) Valid() bool {
+	switch s {
+	case StrategyMax, StrategyFirst, StrategyAverage:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s Strategy) resolve(conflicting []dream.Quality) (dream.Quality, error) {
+	winner := conflicting[0]
+	switch s {
+	case StrategyMax:
+		for _, q := range conflicting[1:] {
+			if q.Intensity() > winner.Intensity() {
+				winner = q
+			}
+		}
+	case StrategyAverage:
+		total := 0
+		for _, q := range conflicting {
+			total += q.Intensity()
+		}
+		// округляем к ближайшему целому
+		return winner.WithIntensity((total*2 + len(conflicting)) / (2 * len(conflicting)))
+	case StrategyFirst:
+	}
+	return winner, nil
+}
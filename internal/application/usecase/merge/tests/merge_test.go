@@ -0,0 +1,181 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/xeniasokk/field-switcher/internal/application/usecase/merge"
+	"github.com/xeniasokk/field-switcher/internal/application/validation"
+	"github.com/xeniasokk/field-switcher/internal/domain/dream"
+	"github.com/xeniasokk/field-switcher/internal/ports"
+	"github.com/xeniasokk/field-switcher/pkg/errors"
+)
+
+// transformerMock выдаёт стек в зависимости от мечты, качества переносит как есть.
+type transformerMock struct {
+	stackByName map[string][]string
+}
+
+func (m *transformerMock) TransformDream(ctx context.Context, d dream.ChildhoodDream) (dream.Adult, error) {
+	_ = ctx
+	f, _ := dream.NewField("Dev", "Team")
+	return dream.NewAdult("Role "+d.DisplayName(), "Desc", f, m.stackByName[d.DisplayName()], d.Qualities(), "comment")
+}
+
+var _ ports.TransformerPort = (*transformerMock)(nil)
+
+func quality(t *testing.T, name, desc string, intensity int) dream.Quality {
+	t.Helper()
+	q, err := dream.NewQualityWithIntensity(name, desc, intensity)
+	if err != nil {
+		t.Fatalf("failed to create quality: %v", err)
+	}
+	return q
+}
+
+func childDream(t *testing.T, name string, qualities ...dream.Quality) dream.ChildhoodDream {
+	t.Helper()
+	f, _ := dream.NewField("Поле", "Среда")
+	d, err := dream.NewChildhoodDream(dream.TypeFootballer, name, "Роль "+name, f, qualities)
+	if err != nil {
+		t.Fatalf("failed to create dream: %v", err)
+	}
+	return d
+}
+
+func TestMergeUseCaseExecute(t *testing.T) {
+	ctx := context.Background()
+
+	footballer := childDream(t, "Футболист",
+		quality(t, "Упорство", "футбольное", 6),
+		quality(t, "Командный дух", "команда", 9),
+	)
+	astronaut := childDream(t, "Космонавт",
+		quality(t, "УПОРСТВО", "космическое", 10),
+		quality(t, "Любопытство", "звёзды", 7),
+	)
+
+	tests := []struct {
+		name          string
+		strategy      merge.Strategy
+		wantIntensity int
+		wantDesc      string
+	}{
+		{
+			name:          "max keeps strongest quality",
+			strategy:      merge.StrategyMax,
+			wantIntensity: 10,
+			wantDesc:      "космическое",
+		},
+		{
+			name:          "first keeps primary dream quality",
+			strategy:      merge.StrategyFirst,
+			wantIntensity: 6,
+			wantDesc:      "футбольное",
+		},
+		{
+			name:          "average rounds intensity",
+			strategy:      merge.StrategyAverage,
+			wantIntensity: 8,
+			wantDesc:      "футбольное",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := merge.NewUseCase(&transformerMock{stackByName: map[string][]string{
+				"Футболист": {"Go", "Git"},
+				"Космонавт": {"git", "C++"},
+			}})
+
+			out, err := uc.Execute(ctx, merge.NewInput(tt.strategy, footballer, astronaut))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			adult := out.Adult()
+			if adult.RoleTitle() != "Role Футболист" {
+				t.Fatalf("expected primary role, got %q", adult.RoleTitle())
+			}
+			if got := adult.Stack(); len(got) != 3 {
+				t.Fatalf("expected deduplicated stack of 3, got %v", got)
+			}
+
+			traits := adult.Traits()
+			if len(traits) != 3 {
+				t.Fatalf("expected 3 merged traits, got %d", len(traits))
+			}
+			persistence := traits[0]
+			if persistence.Intensity() != tt.wantIntensity {
+				t.Fatalf("expected intensity %d, got %d", tt.wantIntensity, persistence.Intensity())
+			}
+			if persistence.Description() != tt.wantDesc {
+				t.Fatalf("expected description %q, got %q", tt.wantDesc, persistence.Description())
+			}
+			if got := persistence.Sources(); len(got) != 2 || got[0] != "Футболист" || got[1] != "Космонавт" {
+				t.Fatalf("expected both dreams as sources, got %v", got)
+			}
+			if got := traits[2].Sources(); len(got) != 1 || got[0] != "Космонавт" {
+				t.Fatalf("expected single source, got %v", got)
+			}
+
+			if len(out.Sources()) != 2 {
+				t.Fatalf("expected 2 source dreams, got %d", len(out.Sources()))
+			}
+			if out.Child().DisplayName() != "Футболист + Космонавт" {
+				t.Fatalf("unexpected merged display name %q", out.Child().DisplayName())
+			}
+		})
+	}
+}
+
+func TestMergeUseCaseValidation(t *testing.T) {
+	ctx := context.Background()
+	uc := merge.NewUseCase(&transformerMock{})
+
+	tests := []struct {
+		name  string
+		input merge.InputModel
+	}{
+		{
+			name:  "no dreams",
+			input: merge.NewInput(merge.StrategyMax),
+		},
+		{
+			name:  "unknown strategy",
+			input: merge.NewInput("random", childDream(t, "Футболист", quality(t, "Q", "D", 1))),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := uc.Execute(ctx, tt.input)
+			if !errors.IsCode(err, errors.CodeValidation) {
+				t.Fatalf("expected validation error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestMergeUseCaseReportsAllViolations(t *testing.T) {
+	v, err := validation.NewValidatorFromConfig(validation.Config{MaxQualities: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	uc := merge.NewUseCase(&transformerMock{}, merge.WithValidator(v))
+	input := merge.NewInput("random",
+		dream.ChildhoodDream{},
+		childDream(t, "Футболист", quality(t, "Q1", "D", 1), quality(t, "Q2", "D", 2)),
+		dream.ChildhoodDream{},
+	)
+
+	_, err = uc.Execute(context.Background(), input)
+	violations, ok := validation.ViolationsOf(err)
+	if !ok {
+		t.Fatalf("expected violations, got %v", err)
+	}
+	// две безымянные мечты, неизвестная стратегия и лишнее качество второй мечты
+	if len(violations) != 4 {
+		t.Fatalf("expected 4 violations, got %d: %v", len(violations), violations)
+	}
+}
@@ -0,0 +1,188 @@
+package merge
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/xeniasokk/field-switcher/internal/application/validation"
+	"github.com/xeniasokk/field-switcher/internal/domain/dream"
+	"github.com/xeniasokk/field-switcher/internal/ports"
+	"github.com/xeniasokk/field-switcher/pkg/errors"
+)
+
+const displayNameSeparator = " + "
+
+type UseCase interface {
+	Execute(ctx context.Context, input InputModel) (OutputModel, error)
+}
+
+type useCase struct {
+	transformer ports.TransformerPort
+	validator   *validation.Validator
+}
+
+type Option func(*useCase)
+
+func WithValidator(v *validation.Validator) Option {
+	return func(uc *useCase) {
+		uc.validator = v
+	}
+}
+
+func NewUseCase(transformer ports.TransformerPort, opts ...Option) UseCase {
+	uc := &useCase{
+		transformer: transformer,
+	}
+	for _, opt := range opts {
+		opt(uc)
+	}
+	return uc
+}
+
+func (uc *useCase) Execute(ctx context.Context, input InputModel) (OutputModel, error) {
+	violations := input.violations(ctx)
+	children := input.Children()
+	if uc.validator != nil {
+		for _, child := range children {
+			violations = append(violations, uc.validator.Check(ctx, child)...)
+		}
+	}
+	if err := violations.Err(); err != nil {
+		return OutputModel{}, errors.Wrap(err, errors.CodeValidation, "invalid input for MergeDreamsUseCase")
+	}
+
+	adults := make([]dream.Adult, 0, len(children))
+	for _, child := range children {
+		adult, err := uc.transformer.TransformDream(ctx, child)
+		if err != nil {
+			return OutputModel{}, errors.Wrap(
+				err, errors.CodeDomainFailure, fmt.Sprintf("transformer failed to process dream %q", child.DisplayName()),
+			)
+		}
+		adults = append(adults, adult)
+	}
+
+	merged, err := mergeChildren(children, input.Strategy())
+	if err != nil {
+		return OutputModel{}, errors.Wrap(err, mergeCode(err), "failed to merge childhood dreams")
+	}
+
+	adult, err := mergeAdults(children, adults, input.Strategy())
+	if err != nil {
+		return OutputModel{}, errors.Wrap(err, mergeCode(err), "failed to merge adult identities")
+	}
+
+	return NewOutput(merged, children, adult), nil
+}
+
+func mergeChildren(children []dream.ChildhoodDream, strategy Strategy) (dream.ChildhoodDream, error) {
+	if len(children) == 1 {
+		return children[0], nil
+	}
+
+	names := make([]string, 0, len(children))
+	roles := make([]string, 0, len(children))
+	qualities := make([][]dream.Quality, 0, len(children))
+	for _, child := range children {
+		names = append(names, child.DisplayName())
+		roles = append(roles, child.DesiredRole())
+		qualities = append(qualities, child.Qualities())
+	}
+
+	merged, err := mergeQualities(names, qualities, strategy)
+	if err != nil {
+		return dream.ChildhoodDream{}, err
+	}
+	return dream.NewChildhoodDream(
+		children[0].Type(),
+		strings.Join(names, displayNameSeparator),
+		strings.Join(dedupe(roles), displayNameSeparator),
+		children[0].Field(),
+		merged,
+	)
+}
+
+func mergeAdults(children []dream.ChildhoodDream, adults []dream.Adult, strategy Strategy) (dream.Adult, error) {
+	// Роль, поле и комментарий берём у первой (основной) мечты
+	primary := adults[0]
+
+	names := make([]string, 0, len(children))
+	var stack []string
+	traits := make([][]dream.Quality, 0, len(adults))
+	for i, adult := range adults {
+		names = append(names, children[i].DisplayName())
+		stack = append(stack, adult.Stack()...)
+		traits = append(traits, adult.Traits())
+	}
+
+	merged, err := mergeQualities(names, traits, strategy)
+	if err != nil {
+		return dream.Adult{}, err
+	}
+	return dream.NewAdult(
+		primary.RoleTitle(),
+		primary.RoleDescription(),
+		primary.Field(),
+		dedupe(stack),
+		merged,
+		primary.Comment(),
+	)
+}
+
+// mergeQualities сравнивает качества через validation.Normalize и сохраняет порядок первого появления.
+func mergeQualities(sources []string, qualities [][]dream.Quality, strategy Strategy) ([]dream.Quality, error) {
+	var order []string
+	groups := make(map[string][]dream.Quality)
+	groupSources := make(map[string][]string)
+
+	for i, list := range qualities {
+		for _, q := range list {
+			key := validation.Normalize(q.Name())
+			if _, ok := groups[key]; !ok {
+				order = append(order, key)
+			}
+			groups[key] = append(groups[key], q)
+			groupSources[key] = appendUnique(groupSources[key], q.Sources()...)
+			groupSources[key] = appendUnique(groupSources[key], sources[i])
+		}
+	}
+
+	merged := make([]dream.Quality, 0, len(order))
+	for _, key := range order {
+		q, err := strategy.resolve(groups[key])
+		if err != nil {
+			return nil, errors.Wrap(err, errors.CodeConflict,
+				fmt.Sprintf("cannot resolve intensities of quality %q", groups[key][0].Name()))
+		}
+		merged = append(merged, q.WithSources(groupSources[key]...))
+	}
+	return merged, nil
+}
+
+func mergeCode(err error) errors.Code {
+	if errors.IsCode(err, errors.CodeConflict) {
+		return errors.CodeConflict
+	}
+	return errors.CodeDomainFailure
+}
+
+func dedupe(values []string) []string {
+	return appendUnique(nil, values...)
+}
+
+func appendUnique(dst []string, values ...string) []string {
+	for _, v := range values {
+		exists := false
+		for _, existing := range dst {
+			if validation.Normalize(existing) == validation.Normalize(v) {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			dst = append(dst, v)
+		}
+	}
+	return dst
+}
@@ -3,8 +3,8 @@ package transform
 import (
 	"context"
 
+	"github.com/xeniasokk/field-switcher/internal/application/validation"
 	"github.com/xeniasokk/field-switcher/internal/domain/dream"
-	domainErrors "github.com/xeniasokk/field-switcher/pkg/errors"
 )
 
 type InputModel struct {
@@ -20,9 +20,17 @@ func (i InputModel) Child() dream.ChildhoodDream {
 }
 
 func (i InputModel) Validate(ctx context.Context) error {
+	return i.violations(ctx).Err()
+}
+
+// violations — структурные нарушения входа; use case дополняет их нарушениями политик.
+func (i InputModel) violations(ctx context.Context) validation.Violations {
 	_ = ctx
+	var violations validation.Violations
 	if i.child.DisplayName() == "" {
-		return domainErrors.NewValidationError("childhood dream has no display name")
+		violations = append(violations, validation.Violation{
+			Policy: validation.PolicyInput, Field: validation.FieldDisplayName, Message: "is required",
+		})
 	}
-	return nil
+	return violations
 }
@@ -0,0 +1,122 @@
+package transform
+
+import (
+	"context"
+	"sync"
+
+	"github.com/xeniasokk/field-switcher/pkg/errors"
+)
+
+type StreamResult struct {
+	Index  int
+	Input  InputModel
+	Output OutputModel
+	Err    error
+}
+
+type streamConfig struct {
+	concurrency int
+	bufferSize  int
+}
+
+const defaultStreamConcurrency = 1
+
+type StreamOption func(*streamConfig)
+
+func WithConcurrency(n int) StreamOption {
+	return func(c *streamConfig) {
+		c.concurrency = n
+	}
+}
+
+func WithResultBuffer(n int) StreamOption {
+	return func(c *streamConfig) {
+		c.bufferSize = n
+	}
+}
+
+type Streamer interface {
+	Stream(ctx context.Context, inputs <-chan InputModel, opts ...StreamOption) <-chan StreamResult
+}
+
+type streamer struct {
+	useCase UseCase
+}
+
+func NewStreamer(uc UseCase) Streamer {
+	return &streamer{useCase: uc}
+}
+
+type indexedInput struct {
+	index int
+	input InputModel
+}
+
+// Stream не читает новый вход, пока воркер не отдал предыдущий результат: медленный
+// потребитель притормаживает источник.
+func (s *streamer) Stream(ctx context.Context, inputs <-chan InputModel, opts ...StreamOption) <-chan StreamResult {
+	cfg := &streamConfig{
+		concurrency: defaultStreamConcurrency,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if cfg.concurrency < 1 {
+		cfg.concurrency = defaultStreamConcurrency
+	}
+	if cfg.bufferSize < 0 {
+		cfg.bufferSize = 0
+	}
+
+	results := make(chan StreamResult, cfg.bufferSize)
+	jobs := make(chan indexedInput)
+
+	go func() {
+		defer close(jobs)
+		index := 0
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case in, ok := <-inputs:
+				if !ok {
+					return
+				}
+				select {
+				case jobs <- indexedInput{index: index, input: in}:
+					index++
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < cfg.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				res := StreamResult{Index: job.index, Input: job.input}
+				if err := ctx.Err(); err != nil {
+					res.Err = errors.Wrap(err, errors.CodeInternal, "stream cancelled")
+				} else {
+					res.Output, res.Err = s.useCase.Execute(ctx, job.input)
+				}
+				select {
+				case results <- res:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	return results
+}
@@ -2,11 +2,17 @@ package tests
 
 import (
 	"context"
+	"reflect"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/xeniasokk/field-switcher/internal/application/usecase/transform"
+	"github.com/xeniasokk/field-switcher/internal/application/validation"
 	"github.com/xeniasokk/field-switcher/internal/domain/dream"
 	"github.com/xeniasokk/field-switcher/internal/ports"
+	"github.com/xeniasokk/field-switcher/pkg/errors"
+	"github.com/xeniasokk/field-switcher/pkg/eventbus"
 )
 
 type transformerMock struct {
@@ -77,3 +83,332 @@ func TestUseCaseExecute(t *testing.T) {
 		})
 	}
 }
+
+func TestUseCaseExecuteWithValidator(t *testing.T) {
+	ctx := context.Background()
+	child, err := dream.NewDefaultFootballerDream()
+	if err != nil {
+		t.Fatalf("failed to create default footballer dream: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		cfg      validation.Config
+		wantErr  bool
+		wantCode errors.Code
+	}{
+		{
+			name:    "default policies accept default dream",
+			cfg:     validation.DefaultConfig(),
+			wantErr: false,
+		},
+		{
+			name:     "policy violation is reported as validation error",
+			cfg:      validation.Config{MaxQualities: 2},
+			wantErr:  true,
+			wantCode: errors.CodeValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := validation.NewValidatorFromConfig(tt.cfg)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			f, _ := dream.NewField("Dev", "Team")
+			a, _ := dream.NewAdult("Role", "Desc", f, nil, nil, "")
+			uc := transform.NewUseCase(&transformerMock{adult: a}, transform.WithValidator(v))
+
+			_, err = uc.Execute(ctx, transform.NewInput(child))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !errors.IsCode(err, tt.wantCode) {
+				t.Fatalf("expected code %v, got %v", tt.wantCode, errors.CodeOf(err))
+			}
+		})
+	}
+}
+
+func TestUseCaseExecuteReportsAllViolations(t *testing.T) {
+	v, err := validation.NewValidatorFromConfig(validation.Config{MinQualities: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	uc := transform.NewUseCase(&transformerMock{}, transform.WithValidator(v))
+
+	// у пустой мечты нет ни имени, ни качеств: оба нарушения приходят одной ошибкой
+	_, err = uc.Execute(context.Background(), transform.NewInput(dream.ChildhoodDream{}))
+	violations, ok := validation.ViolationsOf(err)
+	if !ok {
+		t.Fatalf("expected violations, got %v", err)
+	}
+	var policies []string
+	for _, v := range violations {
+		policies = append(policies, v.Policy)
+	}
+	want := []string{validation.PolicyInput, "quality_count"}
+	if !reflect.DeepEqual(policies, want) {
+		t.Fatalf("violated policies = %v, want %v", policies, want)
+	}
+}
+
+type publisherMock struct {
+	events []string
+}
+
+func (m *publisherMock) Publish(ctx context.Context, event eventbus.Event) error {
+	_ = ctx
+	m.events = append(m.events, event.EventName())
+	return nil
+}
+
+func TestUseCaseExecutePublishesEvents(t *testing.T) {
+	ctx := context.Background()
+	child, err := dream.NewDefaultFootballerDream()
+	if err != nil {
+		t.Fatalf("failed to create default footballer dream: %v", err)
+	}
+	f, _ := dream.NewField("Dev", "Team")
+	a, _ := dream.NewAdult("Role", "Desc", f, nil, nil, "")
+
+	tests := []struct {
+		name       string
+		mockErr    error
+		wantEvents []string
+	}{
+		{
+			name:       "successful transformation",
+			wantEvents: []string{dream.EventDreamValidated, dream.EventDreamTransformed},
+		},
+		{
+			name:       "failed transformation",
+			mockErr:    errors.NewDomainError("boom"),
+			wantEvents: []string{dream.EventDreamValidated, dream.EventTransformationFailed},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pub := &publisherMock{}
+			uc := transform.NewUseCase(&transformerMock{adult: a, err: tt.mockErr}, transform.WithPublisher(pub))
+
+			_, _ = uc.Execute(ctx, transform.NewInput(child))
+
+			if len(pub.events) != len(tt.wantEvents) {
+				t.Fatalf("expected events %v, got %v", tt.wantEvents, pub.events)
+			}
+			for i := range pub.events {
+				if pub.events[i] != tt.wantEvents[i] {
+					t.Fatalf("expected events %v, got %v", tt.wantEvents, pub.events)
+				}
+			}
+		})
+	}
+}
+
+func TestUseCaseStream(t *testing.T) {
+	child, err := dream.NewDefaultFootballerDream()
+	if err != nil {
+		t.Fatalf("failed to create default footballer dream: %v", err)
+	}
+	f, _ := dream.NewField("Dev", "Team")
+	a, _ := dream.NewAdult("Role", "Desc", f, nil, nil, "")
+
+	tests := []struct {
+		name        string
+		inputs      int
+		concurrency int
+		mockErr     error
+	}{
+		{
+			name:        "sequential stream",
+			inputs:      5,
+			concurrency: 1,
+		},
+		{
+			name:        "concurrent stream",
+			inputs:      100,
+			concurrency: 8,
+		},
+		{
+			name:        "errors are delivered per result",
+			inputs:      3,
+			concurrency: 2,
+			mockErr:     errors.NewDomainError("boom"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := transform.NewUseCase(&transformerMock{adult: a, err: tt.mockErr})
+
+			in := make(chan transform.InputModel)
+			go func() {
+				defer close(in)
+				for i := 0; i < tt.inputs; i++ {
+					in <- transform.NewInput(child)
+				}
+			}()
+
+			seen := make(map[int]bool)
+			for res := range transform.NewStreamer(uc).Stream(context.Background(), in, transform.WithConcurrency(tt.concurrency)) {
+				if seen[res.Index] {
+					t.Fatalf("duplicate result index %d", res.Index)
+				}
+				seen[res.Index] = true
+				if (res.Err != nil) != (tt.mockErr != nil) {
+					t.Fatalf("unexpected result error: %v", res.Err)
+				}
+				if res.Err == nil && res.Output.Adult().RoleTitle() != "Role" {
+					t.Fatalf("expected adult role 'Role', got '%s'", res.Output.Adult().RoleTitle())
+				}
+			}
+			if len(seen) != tt.inputs {
+				t.Fatalf("expected %d results, got %d", tt.inputs, len(seen))
+			}
+		})
+	}
+}
+
+func TestUseCaseStreamCancellation(t *testing.T) {
+	child, err := dream.NewDefaultFootballerDream()
+	if err != nil {
+		t.Fatalf("failed to create default footballer dream: %v", err)
+	}
+	f, _ := dream.NewField("Dev", "Team")
+	a, _ := dream.NewAdult("Role", "Desc", f, nil, nil, "")
+	uc := transform.NewUseCase(&transformerMock{adult: a})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan transform.InputModel)
+	go func() {
+		for {
+			select {
+			case in <- transform.NewInput(child):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	results := transform.NewStreamer(uc).Stream(ctx, in, transform.WithConcurrency(4))
+	for i := 0; i < 10; i++ {
+		<-results
+	}
+	cancel()
+
+	// канал результатов обязан закрыться, несмотря на бесконечный источник
+	for range results {
+	}
+}
+
+// gatedTransformer держит каждый вызов до закрытия release и считает одновременные вызовы.
+type gatedTransformer struct {
+	adult    dream.Adult
+	started  chan struct{}
+	release  chan struct{}
+	inFlight atomic.Int32
+	peak     atomic.Int32
+}
+
+func (m *gatedTransformer) TransformDream(ctx context.Context, d dream.ChildhoodDream) (dream.Adult, error) {
+	n := m.inFlight.Add(1)
+	defer m.inFlight.Add(-1)
+	for {
+		peak := m.peak.Load()
+		if n <= peak || m.peak.CompareAndSwap(peak, n) {
+			break
+		}
+	}
+	m.started <- struct{}{}
+	<-m.release
+	return m.adult, nil
+}
+
+func TestUseCaseStreamConcurrencyLimit(t *testing.T) {
+	const concurrency, inputs = 3, 12
+	child, err := dream.NewDefaultFootballerDream()
+	if err != nil {
+		t.Fatalf("failed to create default footballer dream: %v", err)
+	}
+	f, _ := dream.NewField("Dev", "Team")
+	a, _ := dream.NewAdult("Role", "Desc", f, nil, nil, "")
+	tr := &gatedTransformer{adult: a, started: make(chan struct{}, inputs), release: make(chan struct{})}
+
+	in := make(chan transform.InputModel, inputs)
+	for i := 0; i < inputs; i++ {
+		in <- transform.NewInput(child)
+	}
+	close(in)
+	results := transform.NewStreamer(transform.NewUseCase(tr)).Stream(
+		context.Background(), in, transform.WithConcurrency(concurrency), transform.WithResultBuffer(inputs),
+	)
+
+	for i := 0; i < concurrency; i++ {
+		<-tr.started
+	}
+	select {
+	case <-tr.started:
+		t.Fatalf("more than %d inputs are transformed at once", concurrency)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(tr.release)
+	count := 0
+	for range results {
+		count++
+	}
+	if count != inputs {
+		t.Fatalf("got %d results, want %d", count, inputs)
+	}
+	if peak := tr.peak.Load(); peak != concurrency {
+		t.Fatalf("peak concurrency = %d, want %d", peak, concurrency)
+	}
+}
+
+func TestUseCaseStreamBackpressure(t *testing.T) {
+	const concurrency, buffer, inputs = 2, 1, 50
+	child, err := dream.NewDefaultFootballerDream()
+	if err != nil {
+		t.Fatalf("failed to create default footballer dream: %v", err)
+	}
+	f, _ := dream.NewField("Dev", "Team")
+	a, _ := dream.NewAdult("Role", "Desc", f, nil, nil, "")
+	uc := transform.NewUseCase(&transformerMock{adult: a})
+
+	var consumed atomic.Int32
+	in := make(chan transform.InputModel)
+	go func() {
+		defer close(in)
+		for i := 0; i < inputs; i++ {
+			in <- transform.NewInput(child)
+			consumed.Add(1)
+		}
+	}()
+
+	results := transform.NewStreamer(uc).Stream(
+		context.Background(), in, transform.WithConcurrency(concurrency), transform.WithResultBuffer(buffer),
+	)
+
+	// пока результаты не читают, поток забирает не больше входов, чем может удержать:
+	// буфер результатов, по одному у каждого воркера и один у читающей горутины
+	const limit = buffer + concurrency + 1
+	time.Sleep(50 * time.Millisecond)
+	if got := consumed.Load(); got > limit {
+		t.Fatalf("stream consumed %d inputs with a stalled consumer, want at most %d", got, limit)
+	}
+
+	count := 0
+	for range results {
+		count++
+		if got := int(consumed.Load()); got > count+limit {
+			t.Fatalf("stream is %d inputs ahead of a slow consumer, want at most %d", got-count, limit)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if count != inputs {
+		t.Fatalf("got %d results, want %d", count, inputs)
+	}
+}
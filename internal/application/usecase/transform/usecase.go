@@ -2,9 +2,13 @@ package transform
 
 import (
 	"context"
+	"time"
 
+	"github.com/xeniasokk/field-switcher/internal/application/validation"
+	"github.com/xeniasokk/field-switcher/internal/domain/dream"
 	"github.com/xeniasokk/field-switcher/internal/ports"
 	"github.com/xeniasokk/field-switcher/pkg/errors"
+	"github.com/xeniasokk/field-switcher/pkg/eventbus"
 )
 
 type UseCase interface {
@@ -13,24 +17,79 @@ type UseCase interface {
 
 type useCase struct {
 	transformer ports.TransformerPort
+	validator   *validation.Validator
+	publisher   ports.EventPublisher
+	now         func() time.Time
 }
 
-func NewUseCase(transformer ports.TransformerPort) UseCase {
-	return &useCase{
+type Option func(*useCase)
+
+func WithValidator(v *validation.Validator) Option {
+	return func(uc *useCase) {
+		uc.validator = v
+	}
+}
+
+func WithPublisher(p ports.EventPublisher) Option {
+	return func(uc *useCase) {
+		uc.publisher = p
+	}
+}
+
+func WithClock(now func() time.Time) Option {
+	return func(uc *useCase) {
+		uc.now = now
+	}
+}
+
+func NewUseCase(transformer ports.TransformerPort, opts ...Option) UseCase {
+	uc := &useCase{
 		transformer: transformer,
+		now:         time.Now,
+	}
+	for _, opt := range opts {
+		opt(uc)
 	}
+	return uc
 }
 
 func (uc *useCase) Execute(ctx context.Context, input InputModel) (OutputModel, error) {
-	if err := input.Validate(ctx); err != nil {
+	child := input.Child()
+
+	if err := uc.validate(ctx, input); err != nil {
+		uc.publish(ctx, dream.TransformationFailed{
+			Dream: child, Stage: dream.StageValidation, Err: err, OccurredAt: uc.now(),
+		})
 		return OutputModel{}, errors.Wrap(err, errors.CodeValidation, "invalid input for TransformDreamUseCase")
 	}
+	uc.publish(ctx, dream.DreamValidated{Dream: child, OccurredAt: uc.now()})
 
-	adult, err := uc.transformer.TransformDream(ctx, input.Child())
+	adult, err := uc.transformer.TransformDream(ctx, child)
 	if err != nil {
+		uc.publish(ctx, dream.TransformationFailed{
+			Dream: child, Stage: dream.StageTransformation, Err: err, OccurredAt: uc.now(),
+		})
 		return OutputModel{}, errors.Wrap(err, errors.CodeDomainFailure, "transformer failed to process dream")
 	}
+	uc.publish(ctx, dream.DreamTransformed{Dream: child, Adult: adult, OccurredAt: uc.now()})
 
-	out := NewOutput(input.Child(), adult)
+	out := NewOutput(child, adult)
 	return out, nil
 }
+
+// validate собирает структурные нарушения и нарушения политик в одну ошибку.
+func (uc *useCase) validate(ctx context.Context, input InputModel) error {
+	violations := input.violations(ctx)
+	if uc.validator != nil {
+		violations = append(violations, uc.validator.Check(ctx, input.Child())...)
+	}
+	return violations.Err()
+}
+
+func (uc *useCase) publish(ctx context.Context, event eventbus.Event) {
+	if uc.publisher == nil {
+		return
+	}
+	// Подписчики не должны влиять на результат трансформации
+	_ = uc.publisher.Publish(ctx, event)
+}
@@ -0,0 +1,92 @@
+package validation
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/xeniasokk/field-switcher/internal/domain/dream"
+	appErrors "github.com/xeniasokk/field-switcher/pkg/errors"
+)
+
+type Config struct {
+	MinQualities    int                        `json:"min_qualities,omitempty"`
+	MaxQualities    int                        `json:"max_qualities,omitempty"`
+	UniqueQualities bool                       `json:"unique_qualities,omitempty"`
+	BannedWords     []string                   `json:"banned_words,omitempty"`
+	Lengths         map[FieldName]LengthLimit  `json:"lengths,omitempty"`
+	Required        map[dream.Type][]FieldName `json:"required,omitempty"`
+}
+
+func DefaultConfig() Config {
+	return Config{
+		UniqueQualities: true,
+		Required: map[dream.Type][]FieldName{
+			dream.TypeFootballer: {FieldDisplayName, FieldDesiredRole, FieldQualities},
+		},
+	}
+}
+
+// ParseConfig отклоняет неизвестные ключи: опечатка в имени политики иначе молча её выключила бы.
+func ParseConfig(data []byte) (Config, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+
+	var cfg Config
+	if err := dec.Decode(&cfg); err != nil {
+		return Config{}, appErrors.Wrap(err, appErrors.CodeValidation, "parse validation config")
+	}
+	if err := dec.Decode(&struct{}{}); !errors.Is(err, io.EOF) {
+		return Config{}, appErrors.NewValidationError("parse validation config: unexpected data after the config object")
+	}
+	return cfg, nil
+}
+
+func LoadConfigFile(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, appErrors.Wrap(err, appErrors.CodeIO, fmt.Sprintf("read validation config %s", path))
+	}
+	return ParseConfig(data)
+}
+
+func (c Config) Policies() ([]Policy, error) {
+	var policies []Policy
+
+	if c.MinQualities > 0 || c.MaxQualities > 0 {
+		p, err := NewQualityCountPolicy(c.MinQualities, c.MaxQualities)
+		if err != nil {
+			return nil, appErrors.Wrap(err, appErrors.CodeValidation, "create quality count policy")
+		}
+		policies = append(policies, p)
+	}
+	if c.UniqueQualities {
+		policies = append(policies, NewUniqueQualitiesPolicy())
+	}
+	if len(c.BannedWords) > 0 {
+		policies = append(policies, NewBannedWordsPolicy(c.BannedWords...))
+	}
+	if len(c.Lengths) > 0 {
+		p, err := NewLengthPolicy(c.Lengths)
+		if err != nil {
+			return nil, appErrors.Wrap(err, appErrors.CodeValidation, "create length policy")
+		}
+		policies = append(policies, p)
+	}
+	if len(c.Required) > 0 {
+		policies = append(policies, NewRequiredFieldsPolicy(c.Required))
+	}
+
+	return policies, nil
+}
+
+func NewValidatorFromConfig(cfg Config) (*Validator, error) {
+	policies, err := cfg.Policies()
+	if err != nil {
+		return nil, err
+	}
+	return NewValidator(policies...), nil
+}
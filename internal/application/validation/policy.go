@@ -0,0 +1,293 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/xeniasokk/field-switcher/internal/domain/dream"
+	appErrors "github.com/xeniasokk/field-switcher/pkg/errors"
+)
+
+type Policy interface {
+	Name() string
+	Check(ctx context.Context, d dream.ChildhoodDream) []Violation
+}
+
+// PolicyInput — имя для структурных проверок входа use case, которые не настраиваются конфигом.
+const PolicyInput = "input"
+
+type FieldName string
+
+const (
+	FieldDisplayName        FieldName = "display_name"
+	FieldDesiredRole        FieldName = "desired_role"
+	FieldFieldName          FieldName = "field_name"
+	FieldFieldEnvironment   FieldName = "field_environment"
+	FieldQualities          FieldName = "qualities"
+	FieldQualityName        FieldName = "quality_name"
+	FieldQualityDescription FieldName = "quality_description"
+)
+
+type QualityCountPolicy struct {
+	min int
+	max int
+}
+
+func NewQualityCountPolicy(minCount, maxCount int) (*QualityCountPolicy, error) {
+	if minCount < 0 || maxCount < 0 {
+		return nil, appErrors.NewValidationError("quality count limits cannot be negative")
+	}
+	if maxCount > 0 && minCount > maxCount {
+		return nil, appErrors.NewValidationError(
+			fmt.Sprintf("min qualities (%d) cannot exceed max qualities (%d)", minCount, maxCount),
+		)
+	}
+	return &QualityCountPolicy{min: minCount, max: maxCount}, nil
+}
+
+func (p *QualityCountPolicy) Name() string { return "quality_count" }
+
+func (p *QualityCountPolicy) Check(ctx context.Context, d dream.ChildhoodDream) []Violation {
+	_ = ctx
+	count := len(d.Qualities())
+	if p.min > 0 && count < p.min {
+		return []Violation{{
+			Policy:  p.Name(),
+			Field:   FieldQualities,
+			Message: fmt.Sprintf("at least %d qualities required, got %d", p.min, count),
+		}}
+	}
+	if p.max > 0 && count > p.max {
+		return []Violation{{
+			Policy:  p.Name(),
+			Field:   FieldQualities,
+			Message: fmt.Sprintf("at most %d qualities allowed, got %d", p.max, count),
+		}}
+	}
+	return nil
+}
+
+type UniqueQualitiesPolicy struct{}
+
+func NewUniqueQualitiesPolicy() *UniqueQualitiesPolicy {
+	return &UniqueQualitiesPolicy{}
+}
+
+func (p *UniqueQualitiesPolicy) Name() string { return "unique_qualities" }
+
+func (p *UniqueQualitiesPolicy) Check(ctx context.Context, d dream.ChildhoodDream) []Violation {
+	_ = ctx
+	var violations []Violation
+	seen := make(map[string]string)
+	for _, q := range d.Qualities() {
+		key := Normalize(q.Name())
+		if first, ok := seen[key]; ok {
+			violations = append(violations, Violation{
+				Policy:  p.Name(),
+				Field:   FieldQualityName,
+				Value:   q.Name(),
+				Message: fmt.Sprintf("quality %q duplicates %q", q.Name(), first),
+			})
+			continue
+		}
+		seen[key] = q.Name()
+	}
+	return violations
+}
+
+type BannedWordsPolicy struct {
+	words [][]string
+}
+
+func NewBannedWordsPolicy(words ...string) *BannedWordsPolicy {
+	p := &BannedWordsPolicy{}
+	for _, w := range words {
+		if tokens := tokenize(w); len(tokens) > 0 {
+			p.words = append(p.words, tokens)
+		}
+	}
+	return p
+}
+
+func (p *BannedWordsPolicy) Name() string { return "banned_words" }
+
+func (p *BannedWordsPolicy) Check(ctx context.Context, d dream.ChildhoodDream) []Violation {
+	_ = ctx
+	if len(p.words) == 0 {
+		return nil
+	}
+	var violations []Violation
+	for _, v := range textValues(d) {
+		tokens := tokenize(v.value)
+		for _, banned := range p.words {
+			if containsSequence(tokens, banned) {
+				violations = append(violations, Violation{
+					Policy:  p.Name(),
+					Field:   v.field,
+					Value:   v.value,
+					Message: fmt.Sprintf("contains banned word %q", strings.Join(banned, " ")),
+				})
+			}
+		}
+	}
+	return violations
+}
+
+type LengthLimit struct {
+	Min int `json:"min,omitempty"`
+	Max int `json:"max,omitempty"`
+}
+
+type LengthPolicy struct {
+	limits map[FieldName]LengthLimit
+}
+
+func NewLengthPolicy(limits map[FieldName]LengthLimit) (*LengthPolicy, error) {
+	copied := make(map[FieldName]LengthLimit, len(limits))
+	for field, l := range limits {
+		if l.Min < 0 || l.Max < 0 {
+			return nil, appErrors.NewValidationError(
+				fmt.Sprintf("length limits for %s cannot be negative", field),
+			)
+		}
+		if l.Max > 0 && l.Min > l.Max {
+			return nil, appErrors.NewValidationError(
+				fmt.Sprintf("min length (%d) for %s cannot exceed max length (%d)", l.Min, field, l.Max),
+			)
+		}
+		copied[field] = l
+	}
+	return &LengthPolicy{limits: copied}, nil
+}
+
+func (p *LengthPolicy) Name() string { return "length" }
+
+func (p *LengthPolicy) Check(ctx context.Context, d dream.ChildhoodDream) []Violation {
+	_ = ctx
+	var violations []Violation
+	for _, v := range textValues(d) {
+		limit, ok := p.limits[v.field]
+		if !ok {
+			continue
+		}
+		length := utf8.RuneCountInString(v.value)
+		switch {
+		case limit.Min > 0 && length < limit.Min:
+			violations = append(violations, Violation{
+				Policy:  p.Name(),
+				Field:   v.field,
+				Value:   v.value,
+				Message: fmt.Sprintf("must be at least %d characters, got %d", limit.Min, length),
+			})
+		case limit.Max > 0 && length > limit.Max:
+			violations = append(violations, Violation{
+				Policy:  p.Name(),
+				Field:   v.field,
+				Value:   v.value,
+				Message: fmt.Sprintf("must be at most %d characters, got %d", limit.Max, length),
+			})
+		}
+	}
+	return violations
+}
+
+type RequiredFieldsPolicy struct {
+	required map[dream.Type][]FieldName
+}
+
+func NewRequiredFieldsPolicy(required map[dream.Type][]FieldName) *RequiredFieldsPolicy {
+	copied := make(map[dream.Type][]FieldName, len(required))
+	for t, fields := range required {
+		copied[t] = append([]FieldName(nil), fields...)
+	}
+	return &RequiredFieldsPolicy{required: copied}
+}
+
+func (p *RequiredFieldsPolicy) Name() string { return "required_fields" }
+
+func (p *RequiredFieldsPolicy) Check(ctx context.Context, d dream.ChildhoodDream) []Violation {
+	_ = ctx
+	fields, ok := p.required[d.Type()]
+	if !ok {
+		return nil
+	}
+	values := textValues(d)
+	var violations []Violation
+	for _, field := range fields {
+		if field == FieldQualities {
+			if len(d.Qualities()) == 0 {
+				violations = append(violations, p.missing(d.Type(), field))
+			}
+			continue
+		}
+		for _, v := range values {
+			if v.field == field && strings.TrimSpace(v.value) == "" {
+				violations = append(violations, p.missing(d.Type(), field))
+				break
+			}
+		}
+	}
+	return violations
+}
+
+func (p *RequiredFieldsPolicy) missing(t dream.Type, field FieldName) Violation {
+	return Violation{
+		Policy:  p.Name(),
+		Field:   field,
+		Message: fmt.Sprintf("is required for dream type %s", t),
+	}
+}
+
+// Normalize: NFKC, нижний регистр, слова через один пробел.
+func Normalize(s string) string {
+	return strings.Join(tokenize(s), " ")
+}
+
+func tokenize(s string) []string {
+	s = norm.NFKC.String(s)
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func containsSequence(tokens, seq []string) bool {
+	for i := 0; i+len(seq) <= len(tokens); i++ {
+		match := true
+		for j := range seq {
+			if tokens[i+j] != seq[j] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
+
+type textValue struct {
+	field FieldName
+	value string
+}
+
+func textValues(d dream.ChildhoodDream) []textValue {
+	values := []textValue{
+		{field: FieldDisplayName, value: d.DisplayName()},
+		{field: FieldDesiredRole, value: d.DesiredRole()},
+		{field: FieldFieldName, value: d.Field().Name()},
+		{field: FieldFieldEnvironment, value: d.Field().Environment()},
+	}
+	for _, q := range d.Qualities() {
+		values = append(values,
+			textValue{field: FieldQualityName, value: q.Name()},
+			textValue{field: FieldQualityDescription, value: q.Description()},
+		)
+	}
+	return values
+}
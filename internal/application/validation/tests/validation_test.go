@@ -0,0 +1,267 @@
+package tests
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/xeniasokk/field-switcher/internal/application/validation"
+	"github.com/xeniasokk/field-switcher/internal/domain/dream"
+	"github.com/xeniasokk/field-switcher/pkg/errors"
+)
+
+func newDream(t *testing.T, displayName string, qualityNames ...string) dream.ChildhoodDream {
+	t.Helper()
+	f, err := dream.NewField("Поле", "Стадион")
+	if err != nil {
+		t.Fatalf("failed to create field: %v", err)
+	}
+	qualities := make([]dream.Quality, 0, len(qualityNames))
+	for _, name := range qualityNames {
+		q, err := dream.NewQuality(name, "desc")
+		if err != nil {
+			t.Fatalf("failed to create quality: %v", err)
+		}
+		qualities = append(qualities, q)
+	}
+	d, err := dream.NewChildhoodDream(dream.TypeFootballer, displayName, "Игрок", f, qualities)
+	if err != nil {
+		t.Fatalf("failed to create dream: %v", err)
+	}
+	return d
+}
+
+func TestValidatorPolicies(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name           string
+		cfg            validation.Config
+		dream          func(t *testing.T) dream.ChildhoodDream
+		wantViolations int
+	}{
+		{
+			name: "default config accepts default dream",
+			cfg:  validation.DefaultConfig(),
+			dream: func(t *testing.T) dream.ChildhoodDream {
+				d, err := dream.NewDefaultFootballerDream()
+				if err != nil {
+					t.Fatalf("failed to create default footballer dream: %v", err)
+				}
+				return d
+			},
+			wantViolations: 0,
+		},
+		{
+			name: "too many qualities",
+			cfg:  validation.Config{MaxQualities: 2},
+			dream: func(t *testing.T) dream.ChildhoodDream {
+				return newDream(t, "Футболист", "a", "b", "c")
+			},
+			wantViolations: 1,
+		},
+		{
+			name: "too few qualities",
+			cfg:  validation.Config{MinQualities: 2},
+			dream: func(t *testing.T) dream.ChildhoodDream {
+				return newDream(t, "Футболист", "a")
+			},
+			wantViolations: 1,
+		},
+		{
+			name: "duplicates are case and unicode normalized",
+			cfg:  validation.Config{UniqueQualities: true},
+			dream: func(t *testing.T) dream.ChildhoodDream {
+				// "Ё" в композиции и в разложенной форме (Е + U+0308)
+				return newDream(t, "Футболист", "Упорство", "  упорство ", "Ёмкость", "\u0415\u0308мкость")
+			},
+			wantViolations: 2,
+		},
+		{
+			name: "banned words match whole words only",
+			cfg:  validation.Config{BannedWords: []string{"лентяй", "плохой игрок"}},
+			dream: func(t *testing.T) dream.ChildhoodDream {
+				return newDream(t, "Плохой   ИГРОК", "лентяйство", "Лентяй")
+			},
+			wantViolations: 2,
+		},
+		{
+			name: "length limits",
+			cfg: validation.Config{Lengths: map[validation.FieldName]validation.LengthLimit{
+				validation.FieldDisplayName: {Max: 5},
+				validation.FieldQualityName: {Min: 2},
+			}},
+			dream: func(t *testing.T) dream.ChildhoodDream {
+				return newDream(t, "Футболист", "ab", "c")
+			},
+			wantViolations: 2,
+		},
+		{
+			name: "required fields per dream type",
+			cfg: validation.Config{Required: map[dream.Type][]validation.FieldName{
+				dream.TypeFootballer: {validation.FieldFieldEnvironment, validation.FieldQualityDescription},
+			}},
+			dream: func(t *testing.T) dream.ChildhoodDream {
+				f, _ := dream.NewField("Поле", "")
+				q, _ := dream.NewQuality("Упорство", "")
+				d, err := dream.NewChildhoodDream(dream.TypeFootballer, "Футболист", "Игрок", f, []dream.Quality{q})
+				if err != nil {
+					t.Fatalf("failed to create dream: %v", err)
+				}
+				return d
+			},
+			wantViolations: 2,
+		},
+		{
+			name: "all violations are reported together",
+			cfg: validation.Config{
+				MaxQualities:    1,
+				UniqueQualities: true,
+				BannedWords:     []string{"лентяй"},
+			},
+			dream: func(t *testing.T) dream.ChildhoodDream {
+				return newDream(t, "Лентяй", "a", "A")
+			},
+			wantViolations: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := validation.NewValidatorFromConfig(tt.cfg)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			err = v.Validate(ctx, tt.dream(t))
+			if tt.wantViolations == 0 {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				return
+			}
+
+			if !errors.IsCode(err, errors.CodeValidation) {
+				t.Fatalf("expected validation code, got %v", errors.CodeOf(err))
+			}
+			violations, ok := validation.ViolationsOf(err)
+			if !ok {
+				t.Fatalf("expected violations in error chain, got %v", err)
+			}
+			if len(violations) != tt.wantViolations {
+				t.Fatalf("expected %d violations, got %d: %v", tt.wantViolations, len(violations), violations)
+			}
+		})
+	}
+}
+
+func TestConfigRejectsInvalidLimits(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  validation.Config
+	}{
+		{
+			name: "min greater than max qualities",
+			cfg:  validation.Config{MinQualities: 3, MaxQualities: 1},
+		},
+		{
+			name: "negative length",
+			cfg: validation.Config{Lengths: map[validation.FieldName]validation.LengthLimit{
+				validation.FieldDisplayName: {Min: -1},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := validation.NewValidatorFromConfig(tt.cfg); err == nil {
+				t.Fatalf("expected error")
+			}
+		})
+	}
+}
+
+func TestParseConfig(t *testing.T) {
+	data := []byte(`{
+		"max_qualities": 4,
+		"banned_words": ["лентяй"],
+		"lengths": {"display_name": {"max": 20}},
+		"required": {"footballer": ["desired_role"]}
+	}`)
+
+	cfg, err := validation.ParseConfig(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.MaxQualities != 4 {
+		t.Fatalf("expected max qualities 4, got %d", cfg.MaxQualities)
+	}
+	if cfg.Lengths[validation.FieldDisplayName].Max != 20 {
+		t.Fatalf("expected display name max length 20, got %d", cfg.Lengths[validation.FieldDisplayName].Max)
+	}
+	if len(cfg.Required[dream.TypeFootballer]) != 1 {
+		t.Fatalf("expected one required field for footballer")
+	}
+}
+
+func TestParseConfigRejectsUnknownKeys(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "misspelled policy", data: `{"max_qualites": 4}`},
+		{name: "unknown length key", data: `{"lengths": {"display_name": {"maximum": 20}}}`},
+		{name: "trailing data", data: `{"max_qualities": 4} {}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := validation.ParseConfig([]byte(tt.data))
+			if !errors.IsCode(err, errors.CodeValidation) {
+				t.Fatalf("expected validation error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestLoadConfigFile(t *testing.T) {
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "policies.json")
+	if err := os.WriteFile(valid, []byte(`{"min_qualities": 2, "unique_qualities": true}`), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	broken := filepath.Join(dir, "broken.json")
+	if err := os.WriteFile(broken, []byte(`{`), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		wantCode errors.Code
+		wantMin  int
+	}{
+		{name: "valid file", path: valid, wantMin: 2},
+		{name: "missing file", path: filepath.Join(dir, "missing.json"), wantCode: errors.CodeIO},
+		{name: "broken json", path: broken, wantCode: errors.CodeValidation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := validation.LoadConfigFile(tt.path)
+			if tt.wantCode != "" {
+				if !errors.IsCode(err, tt.wantCode) {
+					t.Fatalf("expected %s error, got %v", tt.wantCode, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if cfg.MinQualities != tt.wantMin {
+				t.Fatalf("MinQualities = %d, want %d", cfg.MinQualities, tt.wantMin)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/xeniasokk/field-switcher/internal/domain/dream"
+	appErrors "github.com/xeniasokk/field-switcher/pkg/errors"
+)
+
+type Violation struct {
+	Policy  string
+	Field   FieldName
+	Value   string
+	Message string
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("%s: %s (%s)", v.Field, v.Message, v.Policy)
+}
+
+type Violations []Violation
+
+func (v Violations) Error() string {
+	parts := make([]string, 0, len(v))
+	for _, violation := range v {
+		parts = append(parts, violation.String())
+	}
+	return fmt.Sprintf("%d validation violation(s): %s", len(v), strings.Join(parts, "; "))
+}
+
+func ViolationsOf(err error) (Violations, bool) {
+	var violations Violations
+	if errors.As(err, &violations) {
+		return violations, true
+	}
+	return nil, false
+}
+
+type Validator struct {
+	policies []Policy
+}
+
+func NewValidator(policies ...Policy) *Validator {
+	copied := make([]Policy, 0, len(policies))
+	for _, p := range policies {
+		if p != nil {
+			copied = append(copied, p)
+		}
+	}
+	return &Validator{policies: copied}
+}
+
+func (v *Validator) Policies() []Policy {
+	return append([]Policy(nil), v.policies...)
+}
+
+// Check возвращает нарушения всех политик сразу, не останавливаясь на первом.
+func (v *Validator) Check(ctx context.Context, d dream.ChildhoodDream) Violations {
+	var violations Violations
+	for _, p := range v.policies {
+		violations = append(violations, p.Check(ctx, d)...)
+	}
+	return violations
+}
+
+func (v *Validator) Validate(ctx context.Context, d dream.ChildhoodDream) error {
+	return v.Check(ctx, d).Err()
+}
+
+func (v Violations) Err() error {
+	if len(v) == 0 {
+		return nil
+	}
+	return appErrors.Wrap(v, appErrors.CodeValidation, "childhood dream violates validation policies")
+}
@@ -0,0 +1,40 @@
+package dream
+
+import "time"
+
+const (
+	EventDreamValidated       = "dream.validated"
+	EventDreamTransformed     = "dream.transformed"
+	EventTransformationFailed = "dream.transformation_failed"
+)
+
+type Stage string
+
+const (
+	StageValidation     Stage = "validation"
+	StageTransformation Stage = "transformation"
+)
+
+type DreamValidated struct {
+	Dream      ChildhoodDream
+	OccurredAt time.Time
+}
+
+func (e DreamValidated) EventName() string { return EventDreamValidated }
+
+type DreamTransformed struct {
+	Dream      ChildhoodDream
+	Adult      Adult
+	OccurredAt time.Time
+}
+
+func (e DreamTransformed) EventName() string { return EventDreamTransformed }
+
+type TransformationFailed struct {
+	Dream      ChildhoodDream
+	Stage      Stage
+	Err        error
+	OccurredAt time.Time
+}
+
+func (e TransformationFailed) EventName() string { return EventTransformationFailed }
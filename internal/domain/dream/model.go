@@ -1,7 +1,6 @@
 package dream
 
 import (
-	"context"
 	"fmt"
 	"slices"
 
@@ -122,9 +121,16 @@ func (r Role) String() string {
 	return string(r)
 }
 
+const (
+	MinIntensity = 0
+	MaxIntensity = 10
+)
+
 type Quality struct {
 	name        string
 	description string
+	intensity   int
+	sources     []string
 }
 
 func NewQuality(name, description string) (Quality, error) {
@@ -134,8 +140,48 @@ func NewQuality(name, description string) (Quality, error) {
 	return Quality{name: name, description: description}, nil
 }
 
+func NewQualityWithIntensity(name, description string, intensity int) (Quality, error) {
+	q, err := NewQuality(name, description)
+	if err != nil {
+		return Quality{}, err
+	}
+	return q.WithIntensity(intensity)
+}
+
 func (q Quality) Name() string        { return q.name }
 func (q Quality) Description() string { return q.description }
+func (q Quality) Intensity() int      { return q.intensity }
+func (q Quality) Sources() []string   { return slices.Clone(q.sources) }
+
+func (q Quality) WithIntensity(intensity int) (Quality, error) {
+	if intensity < MinIntensity || intensity > MaxIntensity {
+		return Quality{}, domainErrors.NewValidationError(
+			fmt.Sprintf("quality intensity must be between %d and %d, got %d", MinIntensity, MaxIntensity, intensity),
+		)
+	}
+	q.intensity = intensity
+	return q, nil
+}
+
+func (q Quality) WithDescription(description string) Quality {
+	q.description = description
+	return q
+}
+
+func (q Quality) WithSources(sources ...string) Quality {
+	q.sources = slices.Clone(sources)
+	return q
+}
+
+// RankByIntensity возвращает качества от самого сильного к самому слабому. При равной
+// интенсивности, в том числе когда она не задана, сохраняется исходный порядок.
+func RankByIntensity(qualities []Quality) []Quality {
+	ranked := slices.Clone(qualities)
+	slices.SortStableFunc(ranked, func(a, b Quality) int {
+		return b.intensity - a.intensity
+	})
+	return ranked
+}
 
 type Field struct {
 	name        string
@@ -315,15 +361,3 @@ func NewDefaultFootballerDream() (ChildhoodDream, error) {
 
 	return d, nil
 }
-
-func (a Adult) Summary(ctx context.Context) string {
-	_ = ctx
-	return fmt.Sprintf(
-		"%s — %s. Поле: %s (%s). Качества: %d",
-		a.roleTitle,
-		a.roleDescription,
-		a.field.Name(),
-		a.field.Environment(),
-		len(a.traits),
-	)
-}
@@ -1,6 +1,7 @@
 package tests
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/xeniasokk/field-switcher/internal/domain/dream"
@@ -185,3 +186,77 @@ func TestNewDevelopmentField(t *testing.T) {
 		})
 	}
 }
+
+func TestNewQualityWithIntensity(t *testing.T) {
+	tests := []struct {
+		name      string
+		intensity int
+		wantErr   bool
+	}{
+		{name: "lower bound", intensity: dream.MinIntensity, wantErr: false},
+		{name: "upper bound", intensity: dream.MaxIntensity, wantErr: false},
+		{name: "above upper bound", intensity: dream.MaxIntensity + 1, wantErr: true},
+		{name: "negative", intensity: -1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := dream.NewQualityWithIntensity("Q", "D", tt.intensity)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewQualityWithIntensity() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && q.Intensity() != tt.intensity {
+				t.Fatalf("expected intensity %d, got %d", tt.intensity, q.Intensity())
+			}
+		})
+	}
+}
+
+func TestQualityWithIntensityRejectsOutOfRange(t *testing.T) {
+	q, err := dream.NewQualityWithIntensity("Q", "D", 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, intensity := range []int{dream.MinIntensity - 1, dream.MaxIntensity + 1} {
+		if _, err := q.WithIntensity(intensity); err == nil {
+			t.Errorf("WithIntensity(%d) expected error instead of clamping", intensity)
+		}
+	}
+	if got, err := q.WithIntensity(dream.MaxIntensity); err != nil || got.Intensity() != dream.MaxIntensity {
+		t.Fatalf("WithIntensity(%d) = %d, %v", dream.MaxIntensity, got.Intensity(), err)
+	}
+}
+
+func TestRankByIntensity(t *testing.T) {
+	quality := func(name string, intensity int) dream.Quality {
+		q, err := dream.NewQualityWithIntensity(name, "", intensity)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return q
+	}
+
+	tests := []struct {
+		name      string
+		qualities []dream.Quality
+		want      []string
+	}{
+		{name: "strongest first", qualities: []dream.Quality{quality("a", 2), quality("b", 9), quality("c", 5)}, want: []string{"b", "c", "a"}},
+		{name: "ties keep order", qualities: []dream.Quality{quality("a", 3), quality("b", 7), quality("c", 3)}, want: []string{"b", "a", "c"}},
+		{name: "unrated keep order", qualities: []dream.Quality{quality("a", 0), quality("b", 0)}, want: []string{"a", "b"}},
+		{name: "empty", qualities: nil, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ranked := dream.RankByIntensity(tt.qualities)
+			got := make([]string, 0, len(ranked))
+			for _, q := range ranked {
+				got = append(got, q.Name())
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("RankByIntensity() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package dream
+
+import (
+	"time"
+
+	domainErrors "github.com/xeniasokk/field-switcher/pkg/errors"
+)
+
+type VersionID string
+
+func (id VersionID) String() string {
+	return string(id)
+}
+
+type Version struct {
+	id        VersionID
+	personID  string
+	parent    VersionID
+	adult     Adult
+	message   string
+	createdAt time.Time
+}
+
+func NewVersion(
+	id VersionID,
+	personID string,
+	parent VersionID,
+	adult Adult,
+	message string,
+	createdAt time.Time,
+) (Version, error) {
+	if id == "" {
+		return Version{}, domainErrors.NewValidationError("version id cannot be empty")
+	}
+	if personID == "" {
+		return Version{}, domainErrors.NewValidationError("person id cannot be empty")
+	}
+	if adult.RoleTitle() == "" {
+		return Version{}, domainErrors.NewValidationError("version must hold an adult identity")
+	}
+	if message == "" {
+		return Version{}, domainErrors.NewValidationError("change message cannot be empty")
+	}
+	return Version{
+		id:        id,
+		personID:  personID,
+		parent:    parent,
+		adult:     adult,
+		message:   message,
+		createdAt: createdAt,
+	}, nil
+}
+
+func (v Version) ID() VersionID        { return v.id }
+func (v Version) PersonID() string     { return v.personID }
+func (v Version) Parent() VersionID    { return v.parent }
+func (v Version) Adult() Adult         { return v.adult }
+func (v Version) Message() string      { return v.message }
+func (v Version) CreatedAt() time.Time { return v.createdAt }
+func (v Version) IsRoot() bool         { return v.parent == "" }
@@ -0,0 +1,11 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/xeniasokk/field-switcher/pkg/eventbus"
+)
+
+type EventPublisher interface {
+	Publish(ctx context.Context, event eventbus.Event) error
+}
@@ -1,7 +1,27 @@
 package ports
 
-import "context"
+import (
+	"context"
+	"io"
+)
 
 type FormatterPort interface {
 	Format(ctx context.Context, vm ViewModel) (string, error)
 }
+
+// StreamFormatterPort пишет результат в w по мере готовности, не собирая его целиком в памяти.
+// Ошибки записи в w возвращаются с кодом IO.
+type StreamFormatterPort interface {
+	FormatterPort
+	FormatTo(ctx context.Context, w io.Writer, vm ViewModel) error
+}
+
+// BatchFormatterPort рендерит сразу несколько результатов в один документ (например, таблицу на команду).
+type BatchFormatterPort interface {
+	FormatAll(ctx context.Context, vms []ViewModel) (string, error)
+}
+
+type BatchStreamFormatterPort interface {
+	BatchFormatterPort
+	FormatAllTo(ctx context.Context, w io.Writer, vms []ViewModel) error
+}
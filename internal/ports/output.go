@@ -6,3 +6,8 @@ type OutputModel interface {
 	Child() dream.ChildhoodDream
 	Adult() dream.Adult
 }
+
+type MultiSourceOutput interface {
+	OutputModel
+	Sources() []dream.ChildhoodDream
+}
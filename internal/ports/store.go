@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/xeniasokk/field-switcher/internal/domain/dream"
+)
+
+type IdentityStore interface {
+	// Append сохраняет версию, только если её родитель совпадает с текущей головой истории.
+	Append(ctx context.Context, v dream.Version) error
+	List(ctx context.Context, personID string) ([]dream.Version, error)
+	Get(ctx context.Context, personID string, id dream.VersionID) (dream.Version, error)
+}
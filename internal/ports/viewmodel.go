@@ -1,10 +1,9 @@
 package ports
 
-import "github.com/xeniasokk/field-switcher/internal/domain/dream"
+import "github.com/xeniasokk/field-switcher/pkg/document"
 
 type ViewModel interface {
-	Title() string
-	Childhood() dream.ChildhoodDream
-	Adult() dream.Adult
-	Note() string
+	// Document — результат в виде нейтрального документа; только по нему рисуют форматтеры,
+	// доменные типы до них не доходят
+	Document() document.Document
 }
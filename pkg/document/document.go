@@ -0,0 +1,114 @@
+package document
+
+import "strings"
+
+// Document хранит подписи ключами каталога сообщений; язык выбирает тот, кто его рисует.
+type Document struct {
+	Title    Text
+	Sections []Section
+	Notes    []Text
+	Quotes   []Quote
+}
+
+type Section struct {
+	Key string
+	// Attrs не печатаются как текст
+	Attrs  map[string]string
+	Blocks []Block
+}
+
+const AttrType = "type"
+
+// Block — Fields или List.
+type Block interface {
+	block()
+}
+
+type Fields []Field
+
+type Field struct {
+	Label string
+	Value Text
+}
+
+type List struct {
+	Label     string
+	RefsLabel string
+	Items     []Item
+}
+
+type Item struct {
+	Name   Text
+	Detail Text
+	Refs   []string
+	// 0 — вес не задан
+	Weight int
+	Attrs  map[string]string
+	Blocks []Block
+}
+
+type Quote struct {
+	Label string
+	Text  Text
+}
+
+func (Fields) block() {}
+func (List) block()   {}
+
+type Emphasis string
+
+const (
+	EmphasisNone      Emphasis = ""
+	EmphasisTerm      Emphasis = "term"
+	EmphasisHighlight Emphasis = "highlight"
+	EmphasisCode      Emphasis = "code"
+	EmphasisMuted     Emphasis = "muted"
+)
+
+type Span struct {
+	Text     string
+	Emphasis Emphasis
+	// Label — ключ подписи уточнения к предыдущему фрагменту
+	Label string
+	// Key — ключ каталога; форматтер подставляет перевод с Args вместо Text
+	Key  string
+	Args []string
+}
+
+// Text: фрагменты склеиваются как есть, пробелы входят в сами фрагменты.
+type Text []Span
+
+func Plain(s string) Text {
+	return Emphasized(s, EmphasisNone)
+}
+
+func Message(key string, args ...string) Text {
+	return Text{{Key: key, Args: args}}
+}
+
+func Emphasized(s string, emphasis Emphasis) Text {
+	if s == "" {
+		return nil
+	}
+	return Text{{Text: s, Emphasis: emphasis}}
+}
+
+func (t Text) String() string {
+	var b strings.Builder
+	for _, s := range t {
+		if s.Label != "" {
+			b.WriteString(" (" + s.Text + ")")
+			continue
+		}
+		b.WriteString(s.Text)
+	}
+	return b.String()
+}
+
+// Emphasis возвращает выделение первого фрагмента.
+func (t Text) Emphasis() Emphasis {
+	if len(t) == 0 {
+		return EmphasisNone
+	}
+	return t[0].Emphasis
+}
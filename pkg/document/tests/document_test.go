@@ -0,0 +1,44 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/xeniasokk/field-switcher/pkg/document"
+)
+
+func TestTextString(t *testing.T) {
+	tests := []struct {
+		name         string
+		text         document.Text
+		want         string
+		wantEmphasis document.Emphasis
+	}{
+		{
+			name: "empty plain text has no spans",
+			text: document.Plain(""),
+			want: "",
+		},
+		{
+			name:         "spans are joined as is",
+			text:         append(document.Emphasized("Go", document.EmphasisCode), document.Span{Text: ", SQL"}),
+			want:         "Go, SQL",
+			wantEmphasis: document.EmphasisCode,
+		},
+		{
+			name: "labeled span is put in parentheses",
+			text: append(document.Plain("Поле"), document.Span{Text: "Команда", Emphasis: document.EmphasisMuted, Label: "environment"}),
+			want: "Поле (Команда)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.text.String(); got != tt.want {
+				t.Fatalf("String() = %q, want %q", got, tt.want)
+			}
+			if got := tt.text.Emphasis(); got != tt.wantEmphasis {
+				t.Fatalf("Emphasis() = %q, want %q", got, tt.wantEmphasis)
+			}
+		})
+	}
+}
@@ -8,4 +8,6 @@ const (
 	CodeDomainFailure Code = "DOMAIN_FAILURE"
 	CodeInternal      Code = "INTERNAL"
 	CodeIO            Code = "IO"
+	CodeNotFound      Code = "NOT_FOUND"
+	CodeConflict      Code = "CONFLICT"
 )
@@ -0,0 +1,273 @@
+package eventbus
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"reflect"
+	"sync"
+	"sync/atomic"
+
+	appErrors "github.com/xeniasokk/field-switcher/pkg/errors"
+)
+
+type Event interface {
+	EventName() string
+}
+
+type Handler[E Event] func(ctx context.Context, event E) error
+
+type ErrorHandler func(ctx context.Context, event Event, err error)
+
+type subscription struct {
+	id      uint64
+	async   bool
+	handler func(ctx context.Context, event Event) error
+}
+
+type job struct {
+	ctx   context.Context
+	event Event
+	sub   *subscription
+}
+
+type config struct {
+	queueSize    int
+	workers      int
+	errorHandler ErrorHandler
+}
+
+const (
+	defaultQueueSize = 64
+	defaultWorkers   = 1
+)
+
+type Option func(*config)
+
+func WithQueueSize(size int) Option {
+	return func(c *config) {
+		c.queueSize = size
+	}
+}
+
+func WithWorkers(workers int) Option {
+	return func(c *config) {
+		c.workers = workers
+	}
+}
+
+func WithErrorHandler(h ErrorHandler) Option {
+	return func(c *config) {
+		c.errorHandler = h
+	}
+}
+
+type SubscribeOption func(*subscription)
+
+func Async() SubscribeOption {
+	return func(s *subscription) {
+		s.async = true
+	}
+}
+
+// Bus: Publish ждёт места в очереди, но не из асинхронного обработчика — воркер не может ждать
+// сам себя, поэтому при полной очереди такое событие отбрасывается с ошибкой.
+type Bus struct {
+	mu     sync.RWMutex
+	subs   map[reflect.Type][]*subscription
+	nextID uint64
+	closed bool
+
+	queue      chan job
+	publishers sync.WaitGroup
+	workers    sync.WaitGroup
+	dropped    atomic.Uint64
+	onError    ErrorHandler
+
+	// отменяется, если Shutdown не дождался доставки
+	base   context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+	once   sync.Once
+}
+
+type workerKey struct{}
+
+func New(opts ...Option) *Bus {
+	cfg := &config{
+		queueSize: defaultQueueSize,
+		workers:   defaultWorkers,
+		errorHandler: func(ctx context.Context, event Event, err error) {
+			_ = ctx
+			log.Printf("event subscriber failed: %s: %v", event.EventName(), err)
+		},
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if cfg.queueSize < 0 {
+		cfg.queueSize = 0
+	}
+	if cfg.workers < 1 {
+		cfg.workers = defaultWorkers
+	}
+
+	b := &Bus{
+		subs:    make(map[reflect.Type][]*subscription),
+		queue:   make(chan job, cfg.queueSize),
+		onError: cfg.errorHandler,
+		done:    make(chan struct{}),
+	}
+	b.base, b.cancel = context.WithCancel(context.Background())
+	for i := 0; i < cfg.workers; i++ {
+		b.workers.Add(1)
+		go b.work()
+	}
+	return b
+}
+
+func Subscribe[E Event](b *Bus, h Handler[E], opts ...SubscribeOption) func() {
+	key := reflect.TypeFor[E]()
+
+	b.mu.Lock()
+	b.nextID++
+	sub := &subscription{
+		id: b.nextID,
+		handler: func(ctx context.Context, event Event) error {
+			return h(ctx, event.(E))
+		},
+	}
+	for _, opt := range opts {
+		opt(sub)
+	}
+	b.subs[key] = append(b.subs[key], sub)
+	b.mu.Unlock()
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		subs := b.subs[key]
+		for i, s := range subs {
+			if s.id == sub.id {
+				b.subs[key] = append(subs[:i:i], subs[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+func (b *Bus) Publish(ctx context.Context, event Event) error {
+	if event == nil {
+		return appErrors.NewValidationError("event cannot be nil")
+	}
+
+	b.mu.RLock()
+	if b.closed {
+		b.mu.RUnlock()
+		return appErrors.NewInternalError(
+			fmt.Sprintf("event bus is shut down, dropping %s", event.EventName()),
+		)
+	}
+	subs := append([]*subscription(nil), b.subs[reflect.TypeOf(event)]...)
+	// Shutdown закрывает очередь только после того, как все начатые Publish вернутся
+	b.publishers.Add(1)
+	b.mu.RUnlock()
+	defer b.publishers.Done()
+
+	var err error
+	for _, sub := range subs {
+		if sub.async {
+			if qErr := b.enqueue(ctx, job{ctx: ctx, event: event, sub: sub}); qErr != nil && err == nil {
+				err = qErr
+			}
+		}
+	}
+	for _, sub := range subs {
+		if !sub.async {
+			b.deliver(ctx, event, sub)
+		}
+	}
+	return err
+}
+
+func (b *Bus) enqueue(ctx context.Context, j job) error {
+	if ctx.Value(workerKey{}) != nil {
+		select {
+		case b.queue <- j:
+			return nil
+		default:
+			b.dropped.Add(1)
+			return appErrors.NewInternalError(
+				fmt.Sprintf("event queue is full, dropping %s published from a subscriber", j.event.EventName()),
+			)
+		}
+	}
+	select {
+	case b.queue <- j:
+		return nil
+	case <-ctx.Done():
+		b.dropped.Add(1)
+		return appErrors.Wrap(ctx.Err(), appErrors.CodeInternal, fmt.Sprintf("publish %s", j.event.EventName()))
+	case <-b.base.Done():
+		b.dropped.Add(1)
+		return appErrors.NewInternalError(
+			fmt.Sprintf("event bus is shut down, dropping %s", j.event.EventName()),
+		)
+	}
+}
+
+// Dropped — сколько асинхронных доставок не попало в очередь.
+func (b *Bus) Dropped() uint64 {
+	return b.dropped.Load()
+}
+
+// Shutdown дожидается очереди; если ctx истёк раньше, обработчики получают отмену.
+func (b *Bus) Shutdown(ctx context.Context) error {
+	b.mu.Lock()
+	b.closed = true
+	b.mu.Unlock()
+
+	b.once.Do(func() {
+		go func() {
+			b.publishers.Wait()
+			close(b.queue)
+			b.workers.Wait()
+			b.cancel()
+			close(b.done)
+		}()
+	})
+
+	select {
+	case <-b.done:
+		return nil
+	case <-ctx.Done():
+		b.cancel()
+		return appErrors.Wrap(ctx.Err(), appErrors.CodeInternal, "event bus shutdown interrupted")
+	}
+}
+
+func (b *Bus) work() {
+	defer b.workers.Done()
+	for j := range b.queue {
+		if b.base.Err() != nil {
+			b.dropped.Add(1)
+			continue
+		}
+		ctx, cancel := context.WithCancel(context.WithValue(context.WithoutCancel(j.ctx), workerKey{}, true))
+		stop := context.AfterFunc(b.base, cancel)
+		b.deliver(ctx, j.event, j.sub)
+		stop()
+		cancel()
+	}
+}
+
+func (b *Bus) deliver(ctx context.Context, event Event, sub *subscription) {
+	defer func() {
+		if r := recover(); r != nil {
+			b.onError(ctx, event, appErrors.NewInternalError(fmt.Sprintf("panic in subscriber: %v", r)))
+		}
+	}()
+	if err := sub.handler(ctx, event); err != nil {
+		b.onError(ctx, event, err)
+	}
+}
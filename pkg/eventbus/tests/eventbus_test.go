@@ -0,0 +1,276 @@
+package tests
+
+import (
+	"context"
+	stdErrors "errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/xeniasokk/field-switcher/pkg/errors"
+	"github.com/xeniasokk/field-switcher/pkg/eventbus"
+)
+
+type pinged struct{ n int }
+
+func (pinged) EventName() string { return "pinged" }
+
+type ponged struct{}
+
+func (ponged) EventName() string { return "ponged" }
+
+type pongedEvent struct{}
+
+func (pongedEvent) EventName() string { return "ponged.nested" }
+
+type errorRecorder struct {
+	mu   sync.Mutex
+	errs []error
+}
+
+func (r *errorRecorder) handle(ctx context.Context, event eventbus.Event, err error) {
+	_ = ctx
+	_ = event
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.errs = append(r.errs, err)
+}
+
+func (r *errorRecorder) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.errs)
+}
+
+func TestBusSyncDeliveryIsolatesErrors(t *testing.T) {
+	ctx := context.Background()
+	rec := &errorRecorder{}
+	bus := eventbus.New(eventbus.WithErrorHandler(rec.handle))
+
+	var got []int
+	eventbus.Subscribe(bus, func(ctx context.Context, e pinged) error {
+		return stdErrors.New("first subscriber fails")
+	})
+	eventbus.Subscribe(bus, func(ctx context.Context, e pinged) error {
+		panic("second subscriber panics")
+	})
+	eventbus.Subscribe(bus, func(ctx context.Context, e pinged) error {
+		got = append(got, e.n)
+		return nil
+	})
+	eventbus.Subscribe(bus, func(ctx context.Context, e ponged) error {
+		t.Fatalf("ponged subscriber must not receive pinged events")
+		return nil
+	})
+
+	if err := bus.Publish(ctx, pinged{n: 1}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 1 || got[0] != 1 {
+		t.Fatalf("expected healthy subscriber to receive event, got %v", got)
+	}
+	if rec.count() != 2 {
+		t.Fatalf("expected 2 isolated subscriber errors, got %d", rec.count())
+	}
+}
+
+func TestBusAsyncDeliveryDrainsOnShutdown(t *testing.T) {
+	ctx := context.Background()
+	bus := eventbus.New(eventbus.WithQueueSize(4))
+
+	var mu sync.Mutex
+	var got []int
+	eventbus.Subscribe(bus, func(ctx context.Context, e pinged) error {
+		time.Sleep(time.Millisecond)
+		mu.Lock()
+		defer mu.Unlock()
+		got = append(got, e.n)
+		return nil
+	}, eventbus.Async())
+
+	for i := 0; i < 10; i++ {
+		if err := bus.Publish(ctx, pinged{n: i}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if err := bus.Shutdown(ctx); err != nil {
+		t.Fatalf("unexpected shutdown error: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(got) != 10 {
+		t.Fatalf("expected all 10 async events delivered, got %d", len(got))
+	}
+	for i, n := range got {
+		if n != i {
+			t.Fatalf("expected ordered delivery, got %v", got)
+		}
+	}
+
+	err := bus.Publish(ctx, pinged{})
+	if !errors.IsCode(err, errors.CodeInternal) {
+		t.Fatalf("expected publish after shutdown to fail with internal code, got %v", err)
+	}
+}
+
+func TestBusUnsubscribe(t *testing.T) {
+	ctx := context.Background()
+	bus := eventbus.New()
+
+	calls := 0
+	unsubscribe := eventbus.Subscribe(bus, func(ctx context.Context, e pinged) error {
+		calls++
+		return nil
+	})
+
+	_ = bus.Publish(ctx, pinged{})
+	unsubscribe()
+	_ = bus.Publish(ctx, pinged{})
+
+	if calls != 1 {
+		t.Fatalf("expected 1 call, got %d", calls)
+	}
+}
+
+func TestBusPublishFromHandlerWithFullQueue(t *testing.T) {
+	ctx := context.Background()
+	bus := eventbus.New(eventbus.WithQueueSize(1), eventbus.WithWorkers(1))
+
+	var mu sync.Mutex
+	ponged, accepted := 0, 0
+	var overflow error
+	eventbus.Subscribe(bus, func(ctx context.Context, e pinged) error {
+		// единственный воркер занят этим обработчиком: ждать места в очереди нельзя
+		for i := 0; i < 20; i++ {
+			if err := bus.Publish(ctx, pongedEvent{}); err != nil {
+				overflow = err
+				continue
+			}
+			accepted++
+		}
+		return nil
+	}, eventbus.Async())
+	eventbus.Subscribe(bus, func(ctx context.Context, e pongedEvent) error {
+		mu.Lock()
+		defer mu.Unlock()
+		ponged++
+		return nil
+	}, eventbus.Async())
+
+	if err := bus.Publish(ctx, pinged{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		// даём обработчику отработать до начала слива очереди
+		time.Sleep(10 * time.Millisecond)
+		done <- bus.Shutdown(ctx)
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("unexpected shutdown error: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("bus deadlocked when a handler published into a full queue")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if !errors.IsCode(overflow, errors.CodeInternal) {
+		t.Fatalf("expected overflow to fail with internal code, got %v", overflow)
+	}
+	if ponged != accepted {
+		t.Fatalf("expected all %d accepted events delivered, got %d", accepted, ponged)
+	}
+	if got := bus.Dropped(); got != uint64(20-accepted) {
+		t.Fatalf("Dropped() = %d, want %d", got, 20-accepted)
+	}
+}
+
+func TestBusPublishWaitsForQueueSpace(t *testing.T) {
+	bus := eventbus.New(eventbus.WithQueueSize(2), eventbus.WithWorkers(1))
+
+	release := make(chan struct{})
+	eventbus.Subscribe(bus, func(ctx context.Context, e pinged) error {
+		<-release
+		return nil
+	}, eventbus.Async())
+
+	// один обработчик занят, две задачи в очереди — третья публикация ждёт
+	published := make(chan int, 10)
+	go func() {
+		for i := 0; i < 10; i++ {
+			_ = bus.Publish(context.Background(), pinged{n: i})
+			published <- i
+		}
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	if n := len(published); n != 3 {
+		t.Fatalf("expected publisher to block after 3 events, published %d", n)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := bus.Publish(ctx, pinged{}); !errors.IsCode(err, errors.CodeInternal) {
+		t.Fatalf("expected publish into a full queue to fail when ctx expires, got %v", err)
+	}
+
+	close(release)
+	if err := bus.Shutdown(context.Background()); err != nil {
+		t.Fatalf("unexpected shutdown error: %v", err)
+	}
+	if n := len(published); n != 10 {
+		t.Fatalf("expected all 10 events published after release, got %d", n)
+	}
+}
+
+func TestBusShutdownCancelsInFlightHandlers(t *testing.T) {
+	bus := eventbus.New(eventbus.WithQueueSize(4), eventbus.WithWorkers(1))
+
+	started := make(chan struct{})
+	exited := make(chan struct{})
+	calls := 0
+	eventbus.Subscribe(bus, func(ctx context.Context, e pinged) error {
+		calls++
+		if calls == 1 {
+			close(started)
+			<-ctx.Done()
+			close(exited)
+		}
+		return ctx.Err()
+	}, eventbus.Async())
+
+	for i := 0; i < 3; i++ {
+		if err := bus.Publish(context.Background(), pinged{n: i}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := bus.Shutdown(ctx); !errors.IsCode(err, errors.CodeInternal) {
+		t.Fatalf("expected interrupted shutdown to fail with internal code, got %v", err)
+	}
+
+	select {
+	case <-exited:
+	case <-time.After(time.Second):
+		t.Fatalf("in-flight handler was not cancelled")
+	}
+	// воркер выбрасывает остаток очереди и выходит, после чего Shutdown завершается
+	if err := bus.Shutdown(context.Background()); err != nil {
+		t.Fatalf("expected goroutines to exit after cancellation, got %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected queued events to be dropped after cancellation, got %d calls", calls)
+	}
+	if got := bus.Dropped(); got != 2 {
+		t.Fatalf("Dropped() = %d, want 2", got)
+	}
+}
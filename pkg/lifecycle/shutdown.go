@@ -0,0 +1,70 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	appErrors "github.com/xeniasokk/field-switcher/pkg/errors"
+)
+
+type Shutdowner interface {
+	Shutdown(ctx context.Context) error
+}
+
+type ShutdownFunc func(ctx context.Context) error
+
+func (f ShutdownFunc) Shutdown(ctx context.Context) error {
+	return f(ctx)
+}
+
+type shutdownStep struct {
+	name      string
+	component Shutdowner
+}
+
+// ShutdownSequence останавливает компоненты в порядке, обратном регистрации.
+type ShutdownSequence struct {
+	mu    sync.Mutex
+	steps []shutdownStep
+	done  bool
+}
+
+func NewShutdownSequence() *ShutdownSequence {
+	return &ShutdownSequence{}
+}
+
+func (s *ShutdownSequence) Add(name string, component Shutdowner) {
+	if component == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.steps = append(s.steps, shutdownStep{name: name, component: component})
+}
+
+func (s *ShutdownSequence) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	if s.done {
+		s.mu.Unlock()
+		return nil
+	}
+	s.done = true
+	steps := s.steps
+	s.mu.Unlock()
+
+	var errs []error
+	for i := len(steps) - 1; i >= 0; i-- {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, appErrors.Wrap(err, appErrors.CodeInternal, "shutdown aborted before "+steps[i].name))
+			break
+		}
+		if err := steps[i].component.Shutdown(ctx); err != nil {
+			errs = append(errs, appErrors.Wrap(err, appErrors.CodeInternal, "shutdown "+steps[i].name))
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return appErrors.Wrap(errors.Join(errs...), appErrors.CodeInternal, "graceful shutdown failed")
+}
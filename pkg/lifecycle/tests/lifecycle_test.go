@@ -0,0 +1,63 @@
+package tests
+
+import (
+	"context"
+	stdErrors "errors"
+	"testing"
+
+	"github.com/xeniasokk/field-switcher/pkg/errors"
+	"github.com/xeniasokk/field-switcher/pkg/lifecycle"
+)
+
+func TestShutdownSequence(t *testing.T) {
+	tests := []struct {
+		name      string
+		failing   string
+		wantOrder []string
+		wantErr   bool
+	}{
+		{
+			name:      "components stop in reverse order",
+			wantOrder: []string{"third", "second", "first"},
+			wantErr:   false,
+		},
+		{
+			name:      "failure does not stop remaining components",
+			failing:   "second",
+			wantOrder: []string{"third", "second", "first"},
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var order []string
+			seq := lifecycle.NewShutdownSequence()
+			for _, name := range []string{"first", "second", "third"} {
+				seq.Add(name, lifecycle.ShutdownFunc(func(ctx context.Context) error {
+					order = append(order, name)
+					if name == tt.failing {
+						return stdErrors.New("boom")
+					}
+					return nil
+				}))
+			}
+
+			err := seq.Shutdown(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Shutdown() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !errors.IsCode(err, errors.CodeInternal) {
+				t.Fatalf("expected internal code, got %v", errors.CodeOf(err))
+			}
+			if len(order) != len(tt.wantOrder) {
+				t.Fatalf("expected order %v, got %v", tt.wantOrder, order)
+			}
+			for i := range order {
+				if order[i] != tt.wantOrder[i] {
+					t.Fatalf("expected order %v, got %v", tt.wantOrder, order)
+				}
+			}
+		})
+	}
+}